@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/spf13/cobra"
+
+	"github.com/marv972228/sandbox_judge/internal/judge"
+	"github.com/marv972228/sandbox_judge/internal/stats"
+)
+
+// significanceLevel is the p-value below which a difference is reported as significant
+const significanceLevel = 0.05
+
+// benchCmd times a solution, or compares two, over repeated runs
+var benchCmd = &cobra.Command{
+	Use:   "bench <problem-id> <solution-file> [other-solution-file]",
+	Short: "Benchmark a solution with repeated runs",
+	Long: `Run every test case several times and report min, median, p95 and
+standard deviation of the run time, plus peak memory, per test and in total.
+
+With a second solution (e.g. the same algorithm in another language), both
+are benchmarked and compared test by test. A Mann-Whitney U test says whether
+each difference is statistically significant; use --repeat 10 or more for a
+meaningful p-value.`,
+	Args: cobra.RangeArgs(2, 3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		problemID := args[0]
+		solutionFiles := args[1:]
+		repeat, _ := cmd.Flags().GetInt("repeat")
+
+		for _, path := range solutionFiles {
+			if _, err := os.Stat(path); os.IsNotExist(err) {
+				return fmt.Errorf("solution file not found: %s", path)
+			}
+		}
+
+		j, err := newJudge(judge.Config{})
+		if err != nil {
+			return err
+		}
+		defer j.Close()
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		results := make([]*judge.BenchResult, len(solutionFiles))
+		for i, path := range solutionFiles {
+			fmt.Printf("Benchmarking %s...\n", path)
+			results[i], err = j.Bench(ctx, problemID, path, judge.BenchConfig{Repeat: repeat})
+			if err != nil {
+				return err
+			}
+			fmt.Printf("\n%s (%s, %d runs per test)\n", path, results[i].Language, results[i].Repeat)
+			printBenchTable(results[i])
+			fmt.Println()
+		}
+
+		if len(results) == 2 {
+			printBenchComparison(solutionFiles, results[0], results[1])
+		}
+		return nil
+	},
+}
+
+// printBenchTable prints per-test and total timing statistics
+func printBenchTable(r *judge.BenchResult) {
+	fmt.Printf("  %-16s %9s %9s %9s %9s %10s  %s\n", "Test", "Min", "Median", "P95", "StdDev", "Peak mem", "Verdict")
+	for _, bt := range r.Tests {
+		fmt.Printf("  %-16s %s %10s  %s\n", bt.TestCase.Name, formatSummary(bt.Time), formatBytes(bt.PeakMemory), colorVerdict(bt.Verdict))
+	}
+	fmt.Printf("  %-16s %s %10s\n", "Total", formatSummary(r.Total), formatBytes(r.PeakMemory))
+}
+
+// printBenchComparison prints median times of two solutions side by side
+// and whether each difference is significant
+func printBenchComparison(files []string, a, b *judge.BenchResult) {
+	fmt.Printf("Comparison (A = %s, B = %s)\n", files[0], files[1])
+	fmt.Printf("  %-16s %9s %9s %7s %7s\n", "Test", "A median", "B median", "B/A", "p")
+
+	row := func(name string, ta, tb []float64) {
+		c := stats.Compare(ta, tb)
+		note := ""
+		if c.Significant(significanceLevel) {
+			note = "  significant"
+		}
+		fmt.Printf("  %-16s %9s %9s %6.2fx %7.3f%s\n", name,
+			formatMS(stats.Summarize(ta).Median), formatMS(stats.Summarize(tb).Median), c.Ratio, c.P, note)
+	}
+
+	for i, bt := range a.Tests {
+		if i < len(b.Tests) {
+			row(bt.TestCase.Name, bt.Times, b.Tests[i].Times)
+		}
+	}
+	row("Total", a.Totals, b.Totals)
+}
+
+// formatSummary formats min, median, p95 and standard deviation as columns
+func formatSummary(s stats.Summary) string {
+	return fmt.Sprintf("%9s %9s %9s %9s", formatMS(s.Min), formatMS(s.Median), formatMS(s.P95), formatMS(s.StdDev))
+}
+
+// formatMS formats milliseconds with precision suited to the magnitude
+func formatMS(ms float64) string {
+	if ms < 10 {
+		return fmt.Sprintf("%.1fms", ms)
+	}
+	return fmt.Sprintf("%.0fms", ms)
+}
+
+// formatBytes formats a memory size in megabytes ("-" if not measured)
+func formatBytes(b int64) string {
+	if b <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f MB", float64(b)/(1024*1024))
+}
+
+func init() {
+	benchCmd.Flags().IntP("repeat", "n", judge.DefaultBenchRepeat, "Number of runs per test case")
+
+	rootCmd.AddCommand(benchCmd)
+}
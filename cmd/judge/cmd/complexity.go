@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/marv972228/sandbox_judge/internal/judge"
+	"github.com/marv972228/sandbox_judge/internal/runner"
+)
+
+// complexityCmd estimates a solution's time complexity
+var complexityCmd = &cobra.Command{
+	Use:   "complexity <problem-id> <solution-file>",
+	Short: "Estimate a solution's time complexity empirically",
+	Long: `Run your solution on generated inputs of increasing size, fit candidate
+complexities (O(1) up to O(n^3)) to the median run times, and report the best
+fit. The fitted curve is extrapolated to the problem's maximum input size
+and compared with the time limit.
+
+The problem must declare a generator under scaling: in problem.yaml. Outputs
+are not checked.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		problemID := args[0]
+		solutionFile := args[1]
+		sizes, _ := cmd.Flags().GetIntSlice("sizes")
+		repeat, _ := cmd.Flags().GetInt("repeat")
+
+		if _, err := os.Stat(solutionFile); os.IsNotExist(err) {
+			return fmt.Errorf("solution file not found: %s", solutionFile)
+		}
+
+		j, err := newJudge(judge.Config{})
+		if err != nil {
+			return err
+		}
+		defer j.Close()
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		fmt.Printf("Measuring %s on growing inputs...\n\n", solutionFile)
+
+		result, err := j.Complexity(ctx, problemID, solutionFile, judge.ComplexityConfig{
+			Sizes:  sizes,
+			Repeat: repeat,
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("  %10s %9s %10s\n", "Size", "Time", "Memory")
+		for _, p := range result.Points {
+			line := fmt.Sprintf("  %10d %9s %10s", p.Size, formatMS(p.Time), formatBytes(p.Memory))
+			if p.Verdict != runner.VerdictAccepted {
+				line += "  " + colorVerdict(p.Verdict)
+			}
+			fmt.Println(line)
+		}
+		fmt.Println()
+
+		if len(result.Fits) == 0 {
+			fmt.Println("Not enough sizes passed to fit a curve.")
+		} else {
+			best := result.Fits[0]
+			fmt.Printf("Best fit: %s (R² %.3f)\n", best.Complexity.Name, best.R2)
+			fmt.Print("Also considered:")
+			for _, f := range result.Fits[1:] {
+				fmt.Printf(" %s (R² %.3f)", f.Complexity.Name, f.R2)
+			}
+			fmt.Println()
+			fmt.Printf("Predicted time at n = %d: %v (limit %v, %s)\n",
+				result.MaxSize, result.Predicted.Round(time.Millisecond), result.TimeLimit, result.Language)
+		}
+
+		if result.ExceedsTimeLimit() {
+			fmt.Printf("\n%s: this solution is likely to exceed the time limit at the maximum input size\n",
+				colorVerdict(runner.VerdictTimeLimitExceeded))
+		}
+		return nil
+	},
+}
+
+func init() {
+	complexityCmd.Flags().IntSlice("sizes", nil, "Input sizes to measure, e.g. 100,1000,10000 (default: from problem.yaml)")
+	complexityCmd.Flags().IntP("repeat", "n", judge.DefaultComplexityRepeat, "Generated inputs per size")
+
+	rootCmd.AddCommand(complexityCmd)
+}
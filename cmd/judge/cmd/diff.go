@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/marv972228/sandbox_judge/internal/judge"
+	"github.com/marv972228/sandbox_judge/internal/runner"
+)
+
+// diffCmd compares two solutions test by test
+var diffCmd = &cobra.Command{
+	Use:   "diff <problem-id> <solution-a> <solution-b>",
+	Short: "Find the tests on which two solutions differ",
+	Long: `Run two solutions (e.g. before and after a refactor) over every test case
+and report per-test verdicts with time and memory deltas, plus the tests where
+their outputs disagree under the problem's comparator, even if both are AC.
+
+With --gen, both also run on generated inputs, which have no expected output:
+there, only crashes, timeouts and disagreeing outputs are differences.
+
+The command exits non-zero if the solutions differ on any test.`,
+	Args: cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		problemID := args[0]
+		files := args[1:]
+		genFile, _ := cmd.Flags().GetString("gen")
+		inputs, _ := cmd.Flags().GetInt("inputs")
+		seed, _ := cmd.Flags().GetInt64("seed")
+		verbose, _ := cmd.Flags().GetBool("verbose")
+
+		for _, path := range append(files, genFile) {
+			if path == "" {
+				continue
+			}
+			if _, err := os.Stat(path); os.IsNotExist(err) {
+				return fmt.Errorf("file not found: %s", path)
+			}
+		}
+
+		j, err := newJudge(judge.Config{})
+		if err != nil {
+			return err
+		}
+		defer j.Close()
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		fmt.Printf("Comparing A = %s with B = %s...\n\n", files[0], files[1])
+
+		result, err := j.Diff(ctx, problemID, files[0], files[1], judge.DiffConfig{
+			GeneratorPath: genFile,
+			Inputs:        inputs,
+			Seed:          seed,
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("  %-16s %-5s %9s %-5s %9s %9s %10s\n", "Test", "A", "A time", "B", "B time", "Δ time", "Δ memory")
+		for _, d := range result.Tests {
+			note := ""
+			if d.OutputsDiffer {
+				note = "  outputs differ"
+			}
+			fmt.Printf("  %-16s %s %9s %s %9s %9s %10s%s\n", d.TestCase.Name,
+				padVerdict(d.A.Verdict, 5), formatMS(durationMS(d.A.Duration)),
+				padVerdict(d.B.Verdict, 5), formatMS(durationMS(d.B.Duration)),
+				formatDeltaMS(durationMS(d.B.Duration)-durationMS(d.A.Duration)),
+				formatDeltaBytes(d.A.Memory, d.B.Memory), note)
+		}
+
+		differing := result.Differing()
+		if len(differing) == 0 {
+			fmt.Printf("\nNo differences on %d tests\n", len(result.Tests))
+			return nil
+		}
+
+		fmt.Println("\nDifferences:")
+		for _, d := range differing {
+			if d.A.Verdict != d.B.Verdict {
+				fmt.Printf("  %s: A got %s, B got %s\n", d.TestCase.Name, d.A.Verdict, d.B.Verdict)
+			} else {
+				fmt.Printf("  %s: outputs differ\n", d.TestCase.Name)
+			}
+			if d.Message != "" {
+				fmt.Printf("    %s\n", d.Message)
+			}
+			if verbose && d.TestCase.Input != "" {
+				printBlock("Input", d.TestCase.Input)
+				printBlock("A output", d.A.Actual)
+				printBlock("B output", d.B.Actual)
+			}
+		}
+
+		return fmt.Errorf("solutions differ on %d of %d tests", len(differing), len(result.Tests))
+	},
+}
+
+// durationMS converts a duration to fractional milliseconds
+func durationMS(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// padVerdict colors a verdict and pads it to a column width (the color
+// codes would throw off fmt's padding)
+func padVerdict(v runner.Verdict, width int) string {
+	return colorVerdict(v) + strings.Repeat(" ", max(width-len(v), 0))
+}
+
+// formatDeltaMS formats a time difference with its sign
+func formatDeltaMS(ms float64) string {
+	if ms < 0 {
+		return "-" + formatMS(-ms)
+	}
+	return "+" + formatMS(ms)
+}
+
+// formatDeltaBytes formats a memory difference in megabytes ("-" if either
+// side was not measured)
+func formatDeltaBytes(a, b int64) string {
+	if a <= 0 || b <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%+.1f MB", float64(b-a)/(1024*1024))
+}
+
+func init() {
+	diffCmd.Flags().String("gen", "", "Input generator, called with the seed as its argument (optional)")
+	diffCmd.Flags().IntP("inputs", "n", judge.DefaultDiffInputs, "Number of generated inputs (with --gen)")
+	diffCmd.Flags().Int64("seed", 1, "Seed of the first generated input")
+	diffCmd.Flags().BoolP("verbose", "v", false, "Show the input and both outputs of differing visible tests")
+
+	rootCmd.AddCommand(diffCmd)
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/marv972228/sandbox_judge/internal/workspace"
+)
+
+// initCmd writes a starter solution for a problem into the workspace
+var initCmd = &cobra.Command{
+	Use:   "init <problem-id>",
+	Short: "Write a starter solution into the workspace",
+	Long: `Write a starter solution for a problem to <workspace>/<problem-id>/solution.<ext>,
+where "judge run <problem-id>" finds it without a path.
+
+The starter comes from the problem's own template (templates/solution.<ext>
+in the problem directory) if it has one. Otherwise function-signature
+problems get a stub declaring the function, and stdin problems get the
+language's default template with fast I/O boilerplate.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		problemID := args[0]
+		langName, _ := cmd.Flags().GetString("lang")
+		force, _ := cmd.Flags().GetBool("force")
+
+		language, err := workspace.Language(langName)
+		if err != nil {
+			return err
+		}
+
+		loader := getLoader()
+		p, err := loader.Load(problemID)
+		if err != nil {
+			return err
+		}
+
+		content, source, err := workspace.Starter(p, loader.Dir(problemID), language)
+		if err != nil {
+			return err
+		}
+
+		path, err := workspace.New(workspaceDir).Path(problemID, language)
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(path); err == nil && !force {
+			return fmt.Errorf("%s already exists (use --force to overwrite)", path)
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return fmt.Errorf("failed to create workspace: %w", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			return fmt.Errorf("failed to write starter: %w", err)
+		}
+
+		fmt.Printf("Wrote %s (%s)\n", path, source)
+		fmt.Printf("Run it with: judge run %s\n", problemID)
+		return nil
+	},
+}
+
+func init() {
+	initCmd.Flags().StringP("lang", "l", "python", "Language of the starter, e.g. python, cpp, java, go, rust")
+	initCmd.Flags().BoolP("force", "f", false, "Overwrite an existing file")
+
+	rootCmd.AddCommand(initCmd)
+}
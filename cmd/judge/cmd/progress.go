@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/marv972228/sandbox_judge/internal/judge"
+	"github.com/marv972228/sandbox_judge/internal/runner"
+)
+
+// progressBarWidth is the number of cells in the progress bar
+const progressBarWidth = 30
+
+// liveReporter prints test results as the judge reports them. Results are
+// printed in test order, so a finished test waits for the ones before it;
+// on a terminal a progress bar on the last line counts every finished test.
+type liveReporter struct {
+	verbose bool
+	tty     bool
+
+	// skipAfterFailure mirrors fail-fast, which reports every test after
+	// the first failure as skipped even if it already ran
+	skipAfterFailure bool
+	failed           bool
+
+	results   []*judge.TestResult
+	next      int
+	done      int
+	running   int
+	timeLimit time.Duration
+	barShown  bool
+}
+
+// newLiveReporter creates a reporter writing to stdout
+func newLiveReporter(verbose bool, policy judge.Policy) *liveReporter {
+	return &liveReporter{
+		verbose:          verbose,
+		tty:              isTerminal(os.Stdout),
+		skipAfterFailure: policy == judge.PolicyFailFast,
+	}
+}
+
+// OnEvent handles a progress event from the judge
+func (r *liveReporter) OnEvent(e judge.Event) {
+	switch e.Type {
+	case judge.EventCompileEnd:
+		if e.Error == "" {
+			r.results = make([]*judge.TestResult, e.Total)
+			r.timeLimit = e.TimeLimit
+		}
+	case judge.EventTestStart:
+		r.running++
+		r.drawBar()
+	case judge.EventTestEnd:
+		if e.Index >= len(r.results) {
+			return
+		}
+		if e.TestResult.Verdict != runner.VerdictSkipped {
+			r.running--
+		}
+		r.results[e.Index] = e.TestResult
+		r.done++
+		r.flush()
+	case judge.EventRunDone:
+		r.flush()
+		r.clearBar()
+	}
+}
+
+// finish removes the progress bar, e.g. after the run failed
+func (r *liveReporter) finish() {
+	r.clearBar()
+}
+
+// flush prints every finished result that all earlier tests have caught up to
+func (r *liveReporter) flush() {
+	r.clearBar()
+	for r.next < len(r.results) && r.results[r.next] != nil {
+		tr := *r.results[r.next]
+		if r.skipAfterFailure && r.failed {
+			tr = judge.TestResult{TestCase: tr.TestCase, Verdict: runner.VerdictSkipped}
+		}
+		if tr.Verdict != runner.VerdictAccepted && tr.Verdict != runner.VerdictSkipped {
+			r.failed = true
+		}
+		printTestResult(r.next, tr, r.timeLimit, r.verbose)
+		r.next++
+	}
+	r.drawBar()
+}
+
+// drawBar draws the progress bar on the current line (terminals only)
+func (r *liveReporter) drawBar() {
+	if !r.tty || len(r.results) == 0 || r.next == len(r.results) {
+		return
+	}
+
+	filled := progressBarWidth * r.done / len(r.results)
+	bar := strings.Repeat("#", filled) + strings.Repeat(".", progressBarWidth-filled)
+	fmt.Printf("\r\033[K  [%s] %d/%d done, %d running", bar, r.done, len(r.results), r.running)
+	r.barShown = true
+}
+
+// clearBar erases the progress bar so regular output can take its line
+func (r *liveReporter) clearBar() {
+	if r.barShown {
+		fmt.Print("\r\033[K")
+		r.barShown = false
+	}
+}
+
+// printTestResult prints one test's verdict and, on failure, what went wrong
+func printTestResult(i int, tr judge.TestResult, timeLimit time.Duration, verbose bool) {
+	testName := tr.TestCase.Name
+	if testName == "" {
+		testName = fmt.Sprintf("Test %d", i+1)
+	}
+
+	verdictStr := colorVerdict(tr.Verdict)
+	if tr.Verdict == runner.VerdictSkipped {
+		fmt.Printf("  %s: %s\n", testName, verdictStr)
+		return
+	}
+	fmt.Printf("  %s: %s (%v)\n", testName, verdictStr, tr.Duration.Round(time.Millisecond))
+
+	// Say what kind of case broke, and under which limit if it differs
+	failed := tr.Verdict != runner.VerdictAccepted
+	if failed && tr.TestCase.Meta.Description != "" {
+		fmt.Printf("    Case: %s\n", tr.TestCase.Meta.Description)
+	}
+	if tr.Verdict == runner.VerdictTimeLimitExceeded && tr.TimeLimit != timeLimit {
+		fmt.Printf("    Time limit for this test: %v\n", tr.TimeLimit)
+	}
+
+	// Show why the output was rejected
+	if tr.Message != "" {
+		fmt.Printf("    %s\n", tr.Message)
+	}
+
+	// Show diff on WA if verbose; hidden tests never reveal their data
+	if verbose && tr.Verdict == runner.VerdictWrongAnswer && tr.TestCase.Meta.Hidden {
+		fmt.Println("    (hidden test, output not shown)")
+	} else if verbose && tr.Verdict == runner.VerdictWrongAnswer {
+		fmt.Println("    Expected:")
+		for _, line := range strings.Split(tr.Expected, "\n") {
+			fmt.Printf("      %s\n", line)
+		}
+		fmt.Println("    Actual:")
+		for _, line := range strings.Split(tr.Actual, "\n") {
+			fmt.Printf("      %s\n", line)
+		}
+	}
+
+	// Show error on RE/TLE/SE if verbose
+	if verbose && tr.Error != "" {
+		fmt.Printf("    Error: %s\n", tr.Error)
+	}
+}
+
+// isTerminal reports whether f is an interactive terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
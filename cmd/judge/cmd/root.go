@@ -2,15 +2,21 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/marv972228/sandbox_judge/internal/judge"
 	"github.com/marv972228/sandbox_judge/internal/problem"
 	"github.com/marv972228/sandbox_judge/internal/runner"
+	"github.com/marv972228/sandbox_judge/internal/workspace"
 	"github.com/spf13/cobra"
 )
 
@@ -19,8 +25,9 @@ var (
 	Version = "dev"
 
 	// Global flags
-	cfgFile     string
-	problemsDir string
+	cfgFile      string
+	problemsDir  string
+	workspaceDir string
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -42,6 +49,7 @@ func init() {
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.judge.yaml)")
 	rootCmd.PersistentFlags().StringVar(&problemsDir, "problems", "./problems", "path to problems directory")
+	rootCmd.PersistentFlags().StringVar(&workspaceDir, "workspace", "./workspace", "path to your solutions, one directory per problem")
 
 	// Add subcommands
 	rootCmd.AddCommand(versionCmd)
@@ -55,6 +63,24 @@ func getLoader() *problem.Loader {
 	return problem.NewLoader(problemsDir)
 }
 
+// newJudge creates a judge for the configured problems directory, with the
+// Docker images next to it
+func newJudge(cfg judge.Config) (*judge.Judge, error) {
+	absProblemDir, err := filepath.Abs(problemsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve problems directory: %w", err)
+	}
+
+	cfg.ProblemsDir = absProblemDir
+	cfg.DockerDir = filepath.Join(filepath.Dir(absProblemDir), "docker")
+
+	j, err := judge.New(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create judge: %w", err)
+	}
+	return j, nil
+}
+
 // versionCmd prints version information
 var versionCmd = &cobra.Command{
 	Use:   "version",
@@ -107,15 +133,35 @@ var showCmd = &cobra.Command{
 		problemID := args[0]
 		loader := getLoader()
 
+		format, err := outputFormat(cmd)
+		if err != nil {
+			return err
+		}
+
 		p, err := loader.Load(problemID)
 		if err != nil {
 			return err
 		}
 
+		if format == formatJSON {
+			return printJSON(p.API())
+		}
+
 		// Print problem header
 		fmt.Printf("# %s\n", p.Title)
 		fmt.Printf("Difficulty: %s | Tags: %s\n", p.Difficulty, strings.Join(p.Tags, ", "))
 		fmt.Printf("Time Limit: %dms | Memory Limit: %dMB\n", p.TimeLimitMS, p.MemoryLimitMB)
+		if len(p.TimeLimitOverrides) > 0 {
+			langs := make([]string, 0, len(p.TimeLimitOverrides))
+			for lang := range p.TimeLimitOverrides {
+				langs = append(langs, lang)
+			}
+			sort.Strings(langs)
+			for i, lang := range langs {
+				langs[i] = fmt.Sprintf("%s: %s", lang, p.TimeLimitOverrides[lang])
+			}
+			fmt.Printf("Time Limit Overrides: %s\n", strings.Join(langs, ", "))
+		}
 		fmt.Println()
 
 		// Print description
@@ -124,6 +170,16 @@ var showCmd = &cobra.Command{
 		fmt.Println(strings.TrimSpace(p.Description))
 		fmt.Println()
 
+		if p.Signature != nil {
+			fmt.Println("## Function Signature")
+			fmt.Println()
+			fmt.Printf("    %s\n", p.Signature)
+			fmt.Println()
+			fmt.Printf("Implement the function; `judge init %s` writes a stub. Each input line\n", p.ID)
+			fmt.Println("is one argument and the output is the return value, both as JSON.")
+			fmt.Println()
+		}
+
 		// Print input/output format
 		if p.InputFormat != "" {
 			fmt.Println("## Input Format")
@@ -181,101 +237,182 @@ var showCmd = &cobra.Command{
 
 // runCmd runs a solution against a problem
 var runCmd = &cobra.Command{
-	Use:   "run <problem-id> <solution-file>",
+	Use:   "run <problem-id> [solution-file]",
 	Short: "Run solution against problem",
 	Long: `Execute your solution against all test cases for the specified problem.
 
 The solution will be run in a sandboxed container with resource limits.
-Results show verdict (AC/WA/TLE/RE) and timing for each test case.`,
-	Args: cobra.ExactArgs(2),
+Results show verdict (AC/WA/TLE/RE) and timing for each test case.
+
+Without a solution file, the workspace solution written by "judge init" is
+used (<workspace>/<problem-id>/solution.<ext>, the most recently modified
+if there are several languages).
+
+With --watch, the solution is judged again each time it is saved: a compact
+summary and the first failing test's diff are printed, and a save during a
+run cancels it. Each round is a full run, with a fresh container per test.
+
+--test and --exclude select tests by name ("sample/2"), pattern ("hidden/*")
+or number in the full test list ("3"); separate several with commas.`,
+	Args: cobra.RangeArgs(1, 2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		problemID := args[0]
-		solutionFile := args[1]
 		verbose, _ := cmd.Flags().GetBool("verbose")
-		testNum, _ := cmd.Flags().GetInt("test")
+		include, _ := cmd.Flags().GetStringSlice("test")
+		exclude, _ := cmd.Flags().GetStringSlice("exclude")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		jobs, _ := cmd.Flags().GetInt("jobs")
+		policyName, _ := cmd.Flags().GetString("policy")
 
-		// Verify solution file exists
-		if _, err := os.Stat(solutionFile); os.IsNotExist(err) {
-			return fmt.Errorf("solution file not found: %s", solutionFile)
+		policy, err := judge.ParsePolicy(policyName)
+		if err != nil {
+			return err
 		}
+		format, err := outputFormat(cmd)
+		if err != nil {
+			return err
+		}
+
+		if watchMode, _ := cmd.Flags().GetBool("watch"); watchMode {
+			if format != formatText {
+				return fmt.Errorf("--watch only supports text output")
+			}
+			j, err := newJudge(judge.Config{TimeLimit: timeout, Jobs: jobs, Policy: policy})
+			if err != nil {
+				return err
+			}
+			defer j.Close()
 
-		// Get absolute path for problems dir
-		absProblemDir, err := filepath.Abs(problemsDir)
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+			return runWatch(ctx, j, problemID, args[1:], judge.Selection{Include: include, Exclude: exclude})
+		}
+
+		solutionFile, err := solutionPath(problemID, args[1:])
 		if err != nil {
-			return fmt.Errorf("failed to resolve problems directory: %w", err)
+			return err
+		}
+
+		cfg := judge.Config{
+			TimeLimit: timeout,
+			Jobs:      jobs,
+			Policy:    policy,
 		}
 
-		// Get docker directory (relative to problems dir's parent)
-		dockerDir := filepath.Join(filepath.Dir(absProblemDir), "docker")
+		// Print results live as tests finish (JSON is printed once at the end)
+		var reporter *liveReporter
+		if format == formatText {
+			reporter = newLiveReporter(verbose, policy)
+			cfg.Observer = reporter
+		}
 
 		// Create judge
-		j, err := judge.New(judge.Config{
-			ProblemsDir: absProblemDir,
-			DockerDir:   dockerDir,
-		})
+		j, err := newJudge(cfg)
 		if err != nil {
-			return fmt.Errorf("failed to create judge: %w", err)
+			return err
 		}
 		defer j.Close()
 
+		// Ctrl-C stops the run; the tests that finished are still reported
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
 		// Create context with timeout (5 minutes max for entire run)
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
 		defer cancel()
 
-		fmt.Printf("Running %s...\n", problemID)
+		if reporter != nil && len(args) == 1 {
+			fmt.Printf("Running %s (%s)...\n", problemID, solutionFile)
+		} else if reporter != nil {
+			fmt.Printf("Running %s...\n", problemID)
+		}
 
-		var result *judge.Result
-		if testNum > 0 {
-			result, err = j.RunSingleTest(ctx, problemID, solutionFile, testNum)
-		} else {
-			result, err = j.Run(ctx, problemID, solutionFile)
+		result, err := j.Run(ctx, problemID, solutionFile, judge.Selection{Include: include, Exclude: exclude})
+		if reporter != nil {
+			reporter.finish()
 		}
-		if err != nil {
+		if result == nil {
 			return err
 		}
+		// A non-nil err with a result means the run was interrupted, which
+		// is not a usage mistake
+		interrupted := err
+		if interrupted != nil {
+			cmd.SilenceUsage = true
+		}
 
-		// Print results
-		for i, tr := range result.TestResults {
-			testName := tr.TestCase.Name
-			if testName == "" {
-				testName = fmt.Sprintf("Test %d", i+1)
+		if format == formatJSON {
+			if err := printJSON(result.API()); err != nil {
+				return err
 			}
+			return runInterrupted(interrupted)
+		}
 
-			verdictStr := colorVerdict(tr.Verdict)
-			fmt.Printf("  %s: %s (%v)\n", testName, verdictStr, tr.Duration.Round(time.Millisecond))
-
-			// Show diff on WA if verbose
-			if verbose && tr.Verdict == runner.VerdictWrongAnswer {
-				fmt.Println("    Expected:")
-				for _, line := range strings.Split(tr.Expected, "\n") {
-					fmt.Printf("      %s\n", line)
+		// Print subtask breakdown
+		if len(result.Subtasks) > 0 {
+			fmt.Println()
+			fmt.Println("Subtasks:")
+			for _, st := range result.Subtasks {
+				if st.Incomplete {
+					fmt.Printf("  %-16s %13s (not every test was selected)\n", st.Name, "not scored")
+					continue
 				}
-				fmt.Println("    Actual:")
-				for _, line := range strings.Split(tr.Actual, "\n") {
-					fmt.Printf("      %s\n", line)
+				line := fmt.Sprintf("  %-16s %6s/%-6s (%d/%d tests passed)", st.Name,
+					formatPoints(st.Score), formatPoints(st.Points), st.Passed, st.Total)
+				if st.BlockedBy != "" {
+					line += fmt.Sprintf(", requires %s", st.BlockedBy)
 				}
-			}
-
-			// Show error on RE/TLE/SE if verbose
-			if verbose && tr.Error != "" {
-				fmt.Printf("    Error: %s\n", tr.Error)
+				fmt.Println(line)
 			}
 		}
 
 		// Print summary
 		fmt.Println()
+		label := "Result"
+		if interrupted != nil {
+			label = "Partial result"
+		}
 		summaryVerdict := colorVerdict(result.FinalVerdict)
-		if result.FinalVerdict == runner.VerdictAccepted {
-			fmt.Printf("Result: %s (%d/%d tests passed)\n", summaryVerdict, result.Passed, result.Total)
+		if result.Skipped > 0 {
+			fmt.Printf("%s: %s (%d/%d tests passed, %d skipped)\n", label, summaryVerdict, result.Passed, result.Total, result.Skipped)
 		} else {
-			fmt.Printf("Result: %s (%d/%d tests passed)\n", summaryVerdict, result.Passed, result.Total)
+			fmt.Printf("%s: %s (%d/%d tests passed)\n", label, summaryVerdict, result.Passed, result.Total)
+		}
+		if len(result.Subtasks) > 0 {
+			fmt.Printf("Score: %s/%s\n", formatPoints(result.Score), formatPoints(result.MaxScore))
 		}
 		fmt.Printf("Total time: %v\n", result.TotalDuration.Round(time.Millisecond))
+		fmt.Printf("Time limit: %v per test (%s)\n", result.TimeLimit, result.Language)
 
-		return nil
+		return runInterrupted(interrupted)
 	},
 }
 
+// runInterrupted describes why a run stopped early, or returns nil if it
+// did not
+func runInterrupted(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, context.DeadlineExceeded):
+		return fmt.Errorf("run stopped after 5 minutes; unfinished tests were skipped")
+	default:
+		return fmt.Errorf("run interrupted; unfinished tests were skipped")
+	}
+}
+
+// solutionPath returns the solution file given on the command line, or the
+// problem's workspace solution if none was given
+func solutionPath(problemID string, args []string) (string, error) {
+	if len(args) == 0 {
+		return workspace.New(workspaceDir).Find(problemID)
+	}
+	if _, err := os.Stat(args[0]); os.IsNotExist(err) {
+		return "", fmt.Errorf("solution file not found: %s", args[0])
+	}
+	return args[0], nil
+}
+
 // colorVerdict returns a colored verdict string
 func colorVerdict(v runner.Verdict) string {
 	// ANSI color codes
@@ -283,6 +420,7 @@ func colorVerdict(v runner.Verdict) string {
 		green  = "\033[32m"
 		red    = "\033[31m"
 		yellow = "\033[33m"
+		gray   = "\033[90m"
 		reset  = "\033[0m"
 	)
 
@@ -291,6 +429,8 @@ func colorVerdict(v runner.Verdict) string {
 		return green + "AC" + reset
 	case runner.VerdictWrongAnswer:
 		return red + "WA" + reset
+	case runner.VerdictPresentationError:
+		return yellow + "PE" + reset
 	case runner.VerdictTimeLimitExceeded:
 		return yellow + "TLE" + reset
 	case runner.VerdictMemoryLimitExceeded:
@@ -301,6 +441,8 @@ func colorVerdict(v runner.Verdict) string {
 		return red + "CE" + reset
 	case runner.VerdictSystemError:
 		return red + "SE" + reset
+	case runner.VerdictSkipped:
+		return gray + "SKIP" + reset
 	default:
 		return string(v)
 	}
@@ -309,8 +451,45 @@ func colorVerdict(v runner.Verdict) string {
 func init() {
 	// Flags for run command
 	runCmd.Flags().BoolP("verbose", "v", false, "Show detailed output including input/output diff on failure")
-	runCmd.Flags().IntP("test", "t", 0, "Run only a specific test case (0 = all)")
-	runCmd.Flags().Duration("timeout", 0, "Override the problem's time limit")
+	runCmd.Flags().StringSliceP("test", "t", nil, "Run only these tests: names, patterns or numbers, e.g. sample/2, 'hidden/*', 1,4")
+	runCmd.Flags().StringSlice("exclude", nil, "Skip these tests (same forms as --test)")
+	runCmd.Flags().IntP("jobs", "j", 0, "Number of test cases to run in parallel (0 = cores - 1)")
+	runCmd.Flags().String("policy", "all", "Evaluation policy: all, fail-fast, samples-first")
+	runCmd.Flags().Duration("timeout", 0, "Override the problem's time limit (wins over per-language limits)")
+	runCmd.Flags().String("format", formatText, "Output format: text, json")
+	runCmd.Flags().BoolP("watch", "w", false, "Re-run whenever the solution (or its workspace directory) changes")
+
+	showCmd.Flags().String("format", formatText, "Output format: text, json")
+}
+
+// Output formats for commands with a --format flag
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
+
+// outputFormat returns the command's validated --format flag
+func outputFormat(cmd *cobra.Command) (string, error) {
+	format, _ := cmd.Flags().GetString("format")
+	switch format {
+	case formatText, formatJSON:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown format %q (available: %s, %s)", format, formatText, formatJSON)
+	}
+}
+
+// printJSON writes v to stdout as indented JSON in the pkg/api wire format
+func printJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(v)
+}
+
+// formatPoints formats a score without trailing zeros (e.g. 30, 12.5)
+func formatPoints(p float64) string {
+	return strconv.FormatFloat(p, 'f', -1, 64)
 }
 
 // truncate shortens a string to maxLen, adding "..." if truncated.
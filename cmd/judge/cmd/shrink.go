@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/marv972228/sandbox_judge/internal/judge"
+	"github.com/marv972228/sandbox_judge/internal/problem"
+)
+
+// shrinkCmd minimizes the input of a failing test case
+var shrinkCmd = &cobra.Command{
+	Use:   "shrink <problem-id> <solution-file>",
+	Short: "Minimize the input of a failing test case",
+	Long: `Repeatedly shrink a failing test's input (deleting lines and tokens,
+moving numbers toward zero) while your solution keeps failing with the same
+verdict, and print the smallest input found.
+
+Expected outputs for shrunk inputs come from the reference solution: --ref,
+or the problem's own reference if it declares one. If the problem has a
+validator, only inputs it accepts are tried.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		problemID := args[0]
+		solutionFile := args[1]
+		testName, _ := cmd.Flags().GetString("test")
+		refFile, _ := cmd.Flags().GetString("ref")
+		maxAttempts, _ := cmd.Flags().GetInt("max-attempts")
+		save, _ := cmd.Flags().GetBool("save")
+
+		for _, path := range []string{solutionFile, refFile} {
+			if path == "" {
+				continue
+			}
+			if _, err := os.Stat(path); os.IsNotExist(err) {
+				return fmt.Errorf("file not found: %s", path)
+			}
+		}
+
+		j, err := newJudge(judge.Config{})
+		if err != nil {
+			return err
+		}
+		defer j.Close()
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		tc, err := findTestCase(ctx, j, problemID, testName)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Shrinking %s (%d bytes)...\n", tc.Name, len(tc.Input))
+
+		result, err := j.Shrink(ctx, problemID, solutionFile, tc, judge.ShrinkConfig{
+			ReferencePath: refFile,
+			MaxAttempts:   maxAttempts,
+		})
+		if err != nil {
+			return err
+		}
+
+		minimal := result.Minimal
+		fmt.Printf("Smallest failing input after %d attempts: %s (%d bytes)\n",
+			result.Attempts, colorVerdict(minimal.Verdict), len(minimal.TestCase.Input))
+		if result.Exhausted {
+			fmt.Println("  (attempt limit reached; raise --max-attempts to shrink further)")
+		}
+		if minimal.Message != "" {
+			fmt.Printf("  %s\n", minimal.Message)
+		}
+		printBlock("Input", minimal.TestCase.Input)
+		printBlock("Expected (reference)", minimal.TestCase.Expected)
+		printBlock("Actual", minimal.Actual)
+
+		if save {
+			name, err := getLoader().SaveTestCase(problemID, "shrunk", minimal.TestCase.Input, minimal.TestCase.Expected)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("\nSaved as test %s\n", name)
+		}
+		return nil
+	},
+}
+
+// findTestCase loads a problem's test case by name (e.g. "hidden/3")
+func findTestCase(ctx context.Context, j *judge.Judge, problemID, name string) (problem.TestCase, error) {
+	testCases, err := j.TestCases(ctx, problemID)
+	if err != nil {
+		return problem.TestCase{}, err
+	}
+
+	names := make([]string, len(testCases))
+	for i, tc := range testCases {
+		if tc.Name == name {
+			return tc, nil
+		}
+		names[i] = tc.Name
+	}
+	return problem.TestCase{}, fmt.Errorf("test %q not found (available: %s)", name, strings.Join(names, ", "))
+}
+
+func init() {
+	shrinkCmd.Flags().StringP("test", "t", "", "Name of the failing test, e.g. hidden/3 (required)")
+	shrinkCmd.Flags().String("ref", "", "Reference solution that produces expected outputs (default: the problem's reference)")
+	shrinkCmd.Flags().Int("max-attempts", judge.DefaultShrinkAttempts, "Maximum number of candidate inputs to try")
+	shrinkCmd.Flags().Bool("save", false, "Save the shrunk input as a test case under tests/shrunk/")
+	shrinkCmd.MarkFlagRequired("test")
+
+	rootCmd.AddCommand(shrinkCmd)
+}
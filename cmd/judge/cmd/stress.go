@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/marv972228/sandbox_judge/internal/judge"
+)
+
+// stressCmd compares a solution with a reference on generated inputs
+var stressCmd = &cobra.Command{
+	Use:   "stress <problem-id> <solution-file>",
+	Short: "Find an input where a solution disagrees with a reference",
+	Long: `Repeatedly generate random inputs, run both your solution and a trusted
+reference (usually a brute force) on them, and compare the outputs with the
+problem's comparator.
+
+The generator is called with the seed as its only argument and must print
+one input. Stress testing stops at the first disagreement (or TLE, RE, ...)
+and saves the input as a new test case under tests/stress/.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		problemID := args[0]
+		solutionFile := args[1]
+		refFile, _ := cmd.Flags().GetString("ref")
+		genFile, _ := cmd.Flags().GetString("gen")
+		iterations, _ := cmd.Flags().GetInt("iterations")
+		seed, _ := cmd.Flags().GetInt64("seed")
+		save, _ := cmd.Flags().GetBool("save")
+
+		for _, path := range []string{solutionFile, refFile, genFile} {
+			if _, err := os.Stat(path); os.IsNotExist(err) {
+				return fmt.Errorf("file not found: %s", path)
+			}
+		}
+
+		j, err := newJudge(judge.Config{})
+		if err != nil {
+			return err
+		}
+		defer j.Close()
+
+		// Ctrl-C stops the search and reports how far it got
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		fmt.Printf("Stress testing %s against %s...\n", solutionFile, refFile)
+
+		result, err := j.Stress(ctx, problemID, solutionFile, judge.StressConfig{
+			GeneratorPath: genFile,
+			ReferencePath: refFile,
+			Iterations:    iterations,
+			Seed:          seed,
+		})
+		if result != nil && errors.Is(err, context.Canceled) {
+			fmt.Printf("Interrupted: no counterexample found in %d tests.\n", result.Iterations)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		ce := result.Counterexample
+		if ce == nil {
+			fmt.Printf("No counterexample found in %d tests.\n", result.Iterations)
+			return nil
+		}
+
+		fmt.Printf("Found counterexample after %d tests (seed %d): %s\n",
+			result.Iterations, result.Seed, colorVerdict(ce.Verdict))
+		if ce.Message != "" {
+			fmt.Printf("  %s\n", ce.Message)
+		}
+		printBlock("Input", ce.TestCase.Input)
+		printBlock("Expected (reference)", ce.Expected)
+		printBlock("Actual", ce.Actual)
+		if ce.Error != "" {
+			fmt.Printf("  Error: %s\n", ce.Error)
+		}
+
+		if save {
+			name, err := getLoader().SaveTestCase(problemID, "stress", ce.TestCase.Input, ce.TestCase.Expected)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("\nSaved as test %s\n", name)
+		}
+		return nil
+	},
+}
+
+// printBlock prints a labeled, indented multi-line value
+func printBlock(label, text string) {
+	fmt.Printf("  %s:\n", label)
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		fmt.Printf("    %s\n", line)
+	}
+}
+
+func init() {
+	stressCmd.Flags().String("ref", "", "Reference solution (required)")
+	stressCmd.Flags().String("gen", "", "Input generator, called with the seed as its argument (required)")
+	stressCmd.Flags().IntP("iterations", "n", judge.DefaultStressIterations, "Maximum number of inputs to try")
+	stressCmd.Flags().Int64("seed", 1, "Seed of the first input")
+	stressCmd.Flags().Bool("save", true, "Save the counterexample as a test case")
+	stressCmd.MarkFlagRequired("ref")
+	stressCmd.MarkFlagRequired("gen")
+
+	rootCmd.AddCommand(stressCmd)
+}
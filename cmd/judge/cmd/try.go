@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/marv972228/sandbox_judge/internal/judge"
+	"github.com/marv972228/sandbox_judge/internal/runner"
+)
+
+// tryCmd runs a solution on custom input
+var tryCmd = &cobra.Command{
+	Use:   "try <problem-id> <solution-file>",
+	Short: "Run a solution on your own input",
+	Long: `Run your solution on custom input in the sandbox, with the problem's time
+and memory limits, and show its raw stdout and stderr, time and memory.
+
+If problem.yaml names a reference solution, it runs on the same input and
+your output is judged against its output with the problem's comparator. If
+the problem has a validator, inputs that break the constraints are flagged.
+
+The input is read from --input, or from stdin if --input is "-" or omitted.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		problemID := args[0]
+		solutionFile := args[1]
+		inputFile, _ := cmd.Flags().GetString("input")
+
+		if _, err := os.Stat(solutionFile); os.IsNotExist(err) {
+			return fmt.Errorf("solution file not found: %s", solutionFile)
+		}
+
+		input, err := readInput(inputFile)
+		if err != nil {
+			return err
+		}
+
+		j, err := newJudge(judge.Config{})
+		if err != nil {
+			return err
+		}
+		defer j.Close()
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		result, err := j.Try(ctx, problemID, solutionFile, input)
+		if err != nil {
+			return err
+		}
+
+		if result.InvalidInput != "" {
+			fmt.Printf("Warning: the input breaks the constraints: %s\n\n", result.InvalidInput)
+		}
+
+		run := result.Run
+		fmt.Printf("%s in %v, %s (limit %v, %s)\n", colorVerdict(run.Verdict),
+			run.Duration.Round(time.Millisecond), formatBytes(run.MemoryUsed), result.TimeLimit, result.Language)
+		if run.Verdict == runner.VerdictRuntimeError {
+			fmt.Printf("Exit code: %d\n", run.ExitCode)
+		}
+		printRaw("stdout", run.Stdout)
+		printRaw("stderr", run.Stderr)
+
+		if result.Reference == "" {
+			return nil
+		}
+		printRaw("reference output", result.Expected)
+		fmt.Printf("\nCompared with %s: %s\n", result.Reference, colorVerdict(result.Verdict))
+		if result.Message != "" {
+			fmt.Printf("  %s\n", result.Message)
+		}
+		return nil
+	},
+}
+
+// readInput reads custom input from a file, or from stdin for "" or "-"
+func readInput(path string) (string, error) {
+	if path != "" && path != "-" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read input: %w", err)
+		}
+		return string(data), nil
+	}
+
+	if isTerminal(os.Stdin) {
+		fmt.Fprintln(os.Stderr, "Reading input from stdin (end with Ctrl-D)...")
+	}
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", fmt.Errorf("failed to read input: %w", err)
+	}
+	return string(data), nil
+}
+
+// printRaw prints a program's output under a heading, or notes that it is empty
+func printRaw(label, text string) {
+	if text == "" {
+		fmt.Printf("\n%s: (empty)\n", label)
+		return
+	}
+	fmt.Printf("\n%s:\n%s", label, text)
+	if text[len(text)-1] != '\n' {
+		fmt.Println("\n(no trailing newline)")
+	}
+}
+
+func init() {
+	tryCmd.Flags().StringP("input", "i", "-", `File to read the input from ("-" = stdin)`)
+
+	rootCmd.AddCommand(tryCmd)
+}
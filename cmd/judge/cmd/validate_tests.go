@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/spf13/cobra"
+
+	"github.com/marv972228/sandbox_judge/internal/judge"
+)
+
+// validateTestsCmd checks every test input against the problem's constraints
+var validateTestsCmd = &cobra.Command{
+	Use:   "validate-tests <problem-id>",
+	Short: "Check test inputs against the problem's constraints",
+	Long: `Run the problem's validator (validator: in problem.yaml) on every test
+input and report the files it rejects, with the constraint each one breaks.
+
+A validator reads one input on stdin and exits 0 if it is valid. Any other
+exit code rejects the input; the message on stderr (or stdout) is shown.
+The command exits non-zero if any input is rejected.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		problemID := args[0]
+
+		j, err := newJudge(judge.Config{})
+		if err != nil {
+			return err
+		}
+		defer j.Close()
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		fmt.Printf("Validating %s test inputs...\n", problemID)
+
+		result, err := j.ValidateTests(ctx, problemID)
+		if err != nil {
+			return err
+		}
+
+		return reportValidation(result)
+	},
+}
+
+// reportValidation prints the rejected inputs and returns an error if there are any
+func reportValidation(r *judge.ValidationResult) error {
+	invalid := r.Invalid()
+	for _, ic := range invalid {
+		fmt.Printf("  \033[31mINVALID\033[0m %s\n", ic.TestCase.Name)
+		if ic.Message != "" {
+			fmt.Printf("          %s\n", ic.Message)
+		}
+	}
+
+	if len(invalid) > 0 {
+		return fmt.Errorf("validation failed: %d of %d test inputs break the constraints", len(invalid), len(r.Inputs))
+	}
+	fmt.Printf("  All %d test inputs are valid\n", len(r.Inputs))
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(validateTestsCmd)
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/spf13/cobra"
+
+	"github.com/marv972228/sandbox_judge/internal/judge"
+)
+
+// verifyCmd checks that a problem's reference solutions get their expected verdicts
+var verifyCmd = &cobra.Command{
+	Use:   "verify <problem-id>",
+	Short: "Check that reference solutions get their expected verdicts",
+	Long: `If problem.yaml names a validator, first check every test input with it
+(see validate-tests). Then run every solution listed under solutions: in
+problem.yaml against all test cases and check its verdict: AC solutions
+must pass every test, and a solution expected to get e.g. TLE must get it
+on at least one test and fail no test in any other way.
+
+A failed verification means some test breaks the constraints, or the tests
+or limits do not separate good solutions from bad ones. The command exits
+non-zero in that case.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		problemID := args[0]
+
+		j, err := newJudge(judge.Config{})
+		if err != nil {
+			return err
+		}
+		defer j.Close()
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		fmt.Printf("Verifying %s...\n", problemID)
+
+		result, err := j.Verify(ctx, problemID)
+		if err != nil {
+			return err
+		}
+
+		invalid := 0
+		if result.Validation != nil {
+			fmt.Println("Test inputs:")
+			if err := reportValidation(result.Validation); err != nil {
+				invalid = len(result.Validation.Invalid())
+			}
+			fmt.Println("Solutions:")
+		}
+
+		failed := 0
+		for _, sc := range result.Solutions {
+			status := "\033[32mOK\033[0m  "
+			if !sc.OK {
+				status = "\033[31mFAIL\033[0m"
+				failed++
+			}
+
+			line := fmt.Sprintf("  %s %-40s expected %-4s", status, sc.Solution.Path, sc.Solution.Expected)
+			if sc.Result != nil {
+				line += fmt.Sprintf(" got %s (%d/%d tests passed)", colorVerdict(sc.Result.FinalVerdict), sc.Result.Passed, sc.Result.Total)
+			}
+			fmt.Println(line)
+			if sc.Reason != "" {
+				fmt.Printf("       %s\n", sc.Reason)
+			}
+		}
+
+		fmt.Println()
+		if invalid > 0 {
+			return fmt.Errorf("verification failed: %d test inputs break the constraints", invalid)
+		}
+		if failed > 0 {
+			return fmt.Errorf("verification failed: %d of %d solutions did not get their expected verdict", failed, len(result.Solutions))
+		}
+		fmt.Printf("Verification passed: %d solutions got their expected verdicts\n", len(result.Solutions))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(verifyCmd)
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/marv972228/sandbox_judge/internal/judge"
+	"github.com/marv972228/sandbox_judge/internal/runner"
+	"github.com/marv972228/sandbox_judge/internal/watch"
+	"github.com/marv972228/sandbox_judge/internal/workspace"
+)
+
+// watchOutcome is the result of one judging round in watch mode
+type watchOutcome struct {
+	solution string
+	result   *judge.Result
+	err      error
+}
+
+// runWatch judges the solution, then judges it again whenever the watched
+// file (or the workspace directory, without a solution file) changes. A
+// change during a round cancels it. Rounds share the Judge's Docker client,
+// but each one loads the problem again and runs every test in a fresh
+// container, exactly like a single run. It returns when ctx is done, once
+// the last round has stopped, so the caller may close the Judge.
+func runWatch(ctx context.Context, j *judge.Judge, problemID string, args []string, sel judge.Selection) error {
+	target := workspace.New(workspaceDir).Dir(problemID)
+	if len(args) > 0 {
+		target = args[0]
+	}
+	if _, err := os.Stat(target); err != nil {
+		return fmt.Errorf("nothing to watch: %w", err)
+	}
+
+	changes := watch.Changes(ctx, target, watch.DefaultInterval)
+	outcomes := make(chan watchOutcome)
+
+	// stopRound cancels the running round and waits for it to finish, so
+	// rounds never overlap and none outlives the Judge
+	stopRound := func() {}
+	defer func() { stopRound() }()
+
+	start := func() {
+		stopRound()
+		stopRound = func() {}
+
+		solution, err := solutionPath(problemID, args)
+		if err != nil {
+			printWatchHeader(fmt.Sprintf("%s: %v", problemID, err))
+			printWatching(target)
+			return
+		}
+		printWatchHeader(fmt.Sprintf("Judging %s (%s)...", problemID, solution))
+
+		// Same per-run bound as a single run
+		roundCtx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+		abandon := make(chan struct{})
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			result, err := j.Run(roundCtx, problemID, solution, sel)
+			select {
+			case outcomes <- watchOutcome{solution: solution, result: result, err: err}:
+			case <-abandon: // Superseded by a newer change, or stopping
+			}
+		}()
+		stopRound = func() {
+			cancel()
+			close(abandon)
+			<-done
+		}
+	}
+
+	start()
+	for {
+		select {
+		case <-ctx.Done():
+			fmt.Println()
+			return nil
+		case _, ok := <-changes:
+			if !ok {
+				return nil
+			}
+			start()
+		case o := <-outcomes:
+			printWatchOutcome(o)
+			printWatching(target)
+		}
+	}
+}
+
+// printWatchHeader starts a round's output, clearing the screen on a terminal
+func printWatchHeader(line string) {
+	if isTerminal(os.Stdout) {
+		fmt.Print("\033[H\033[2J")
+	}
+	fmt.Printf("[%s] %s\n", time.Now().Format("15:04:05"), line)
+}
+
+// printWatching tells the user the watcher is waiting for the next change
+func printWatching(target string) {
+	fmt.Printf("\nWatching %s for changes (Ctrl-C to stop)\n", target)
+}
+
+// printWatchOutcome prints a compact summary of a round and, if a test
+// failed, the details and diff of the first failure
+func printWatchOutcome(o watchOutcome) {
+	if o.err != nil {
+		if errors.Is(o.err, context.DeadlineExceeded) {
+			fmt.Println("Error: the run took longer than 5 minutes")
+			return
+		}
+		fmt.Printf("Error: %v\n", o.err)
+		return
+	}
+
+	r := o.result
+	summary := fmt.Sprintf("%s %d/%d tests passed in %v (%s, limit %v)", colorVerdict(r.FinalVerdict),
+		r.Passed, r.Total, r.TotalDuration.Round(time.Millisecond), r.Language, r.TimeLimit)
+	if r.Skipped > 0 {
+		summary += fmt.Sprintf(", %d skipped", r.Skipped)
+	}
+	if len(r.Subtasks) > 0 {
+		summary += fmt.Sprintf(", score %s/%s", formatPoints(r.Score), formatPoints(r.MaxScore))
+	}
+	fmt.Println(summary)
+
+	for i, tr := range r.TestResults {
+		if tr.Verdict != runner.VerdictAccepted && tr.Verdict != runner.VerdictSkipped {
+			fmt.Println()
+			fmt.Println("First failure:")
+			printTestResult(i, tr, r.TimeLimit, true)
+			return
+		}
+	}
+}
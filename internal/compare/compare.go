@@ -24,6 +24,24 @@ type Result struct {
 
 	// DiffActual is the actual content at the diff line
 	DiffActual string
+
+	// DiffToken is the token position within DiffLine (1-indexed, 0 if not token-based)
+	DiffToken int
+
+	// Missing lists expected elements absent from the actual output (order-independent comparators)
+	Missing []string
+
+	// Extra lists actual elements absent from the expected output (order-independent comparators)
+	Extra []string
+
+	// Message is a human-readable explanation of the mismatch (empty if match)
+	Message string
+
+	// PresentationError indicates the answer is right but badly formatted
+	PresentationError bool
+
+	// Score is partial credit in [0, 1] for output that did not fully match (checkers only)
+	Score float64
 }
 
 // Comparator defines the interface for output comparison strategies
@@ -54,8 +72,8 @@ func (c *DefaultComparator) Name() string {
 // Compare performs whitespace-tolerant comparison
 func (c *DefaultComparator) Compare(expected, actual string) Result {
 	// Normalize both strings
-	expectedLines := c.normalizeLines(expected)
-	actualLines := c.normalizeLines(actual)
+	expectedLines := normalizeLines(expected)
+	actualLines := normalizeLines(actual)
 
 	// Compare line by line
 	maxLines := len(expectedLines)
@@ -93,7 +111,7 @@ func (c *DefaultComparator) Compare(expected, actual string) Result {
 }
 
 // normalizeLines splits input into lines, trims each line, and removes trailing empty lines
-func (c *DefaultComparator) normalizeLines(s string) []string {
+func normalizeLines(s string) []string {
 	// Normalize line endings (CRLF -> LF)
 	s = strings.ReplaceAll(s, "\r\n", "\n")
 	s = strings.ReplaceAll(s, "\r", "\n")
@@ -0,0 +1,163 @@
+package compare
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultFloatTolerance is used when a problem does not set float_tolerance
+const DefaultFloatTolerance = 1e-6
+
+// FloatComparator performs token-wise comparison with floating point tolerance
+// - Numeric tokens match if the absolute OR relative error is within tolerance
+// - Handles scientific notation, NaN and +/-Inf
+// - Non-numeric tokens must match exactly
+// - Whitespace and line breaks between tokens are ignored
+type FloatComparator struct {
+	// AbsTolerance is the allowed absolute error
+	AbsTolerance float64
+
+	// RelTolerance is the allowed error relative to the expected value
+	RelTolerance float64
+}
+
+// NewFloatComparator creates a FloatComparator that uses tolerance for both
+// absolute and relative error (0 selects DefaultFloatTolerance)
+func NewFloatComparator(tolerance float64) *FloatComparator {
+	if tolerance == 0 {
+		tolerance = DefaultFloatTolerance
+	}
+	return &FloatComparator{
+		AbsTolerance: tolerance,
+		RelTolerance: tolerance,
+	}
+}
+
+// Name returns the comparator's identifier
+func (c *FloatComparator) Name() string {
+	return "float"
+}
+
+// token is a whitespace-separated word with its position in the output
+type token struct {
+	text string
+	line int // 1-indexed line number
+	pos  int // 1-indexed position within the line
+}
+
+// Compare performs token-wise comparison with numeric tolerance
+func (c *FloatComparator) Compare(expected, actual string) Result {
+	expectedLines := normalizeLines(expected)
+	actualLines := normalizeLines(actual)
+
+	result := Result{
+		Match:    true,
+		Expected: strings.Join(expectedLines, "\n"),
+		Actual:   strings.Join(actualLines, "\n"),
+	}
+
+	expTokens := tokenize(expectedLines)
+	actTokens := tokenize(actualLines)
+
+	for i := 0; i < len(expTokens) || i < len(actTokens); i++ {
+		// One side ran out of tokens
+		if i >= len(expTokens) {
+			act := actTokens[i]
+			return c.mismatch(result, act, "", act.text,
+				fmt.Sprintf("line %d, token %d: unexpected extra token %q (expected %d tokens, found %d)",
+					act.line, act.pos, act.text, len(expTokens), len(actTokens)))
+		}
+		if i >= len(actTokens) {
+			exp := expTokens[i]
+			return c.mismatch(result, exp, exp.text, "",
+				fmt.Sprintf("line %d, token %d: missing token, expected %q (expected %d tokens, found %d)",
+					exp.line, exp.pos, exp.text, len(expTokens), len(actTokens)))
+		}
+
+		exp, act := expTokens[i], actTokens[i]
+		expNum, expIsNum := parseNumber(exp.text)
+		actNum, actIsNum := parseNumber(act.text)
+
+		if !expIsNum {
+			if exp.text != act.text {
+				return c.mismatch(result, act, exp.text, act.text,
+					fmt.Sprintf("line %d, token %d: expected %q, found %q", act.line, act.pos, exp.text, act.text))
+			}
+			continue
+		}
+
+		if !actIsNum {
+			return c.mismatch(result, act, exp.text, act.text,
+				fmt.Sprintf("line %d, token %d: expected number %s, found %q", act.line, act.pos, exp.text, act.text))
+		}
+
+		if !c.withinTolerance(expNum, actNum) {
+			absErr := math.Abs(expNum - actNum)
+			relErr := math.Inf(1)
+			if expNum != 0 {
+				relErr = absErr / math.Abs(expNum)
+			}
+			return c.mismatch(result, act, exp.text, act.text,
+				fmt.Sprintf("line %d, token %d: expected %s, found %s (abs error %.3g, rel error %.3g, tolerance %g)",
+					act.line, act.pos, exp.text, act.text, absErr, relErr, c.AbsTolerance))
+		}
+	}
+
+	return result
+}
+
+// mismatch fills in the diff fields of a failed comparison
+func (c *FloatComparator) mismatch(r Result, at token, expected, actual, message string) Result {
+	r.Match = false
+	r.DiffLine = at.line
+	r.DiffToken = at.pos
+	r.DiffExpected = expected
+	r.DiffActual = actual
+	r.Message = message
+	return r
+}
+
+// withinTolerance reports whether actual is close enough to expected
+func (c *FloatComparator) withinTolerance(expected, actual float64) bool {
+	switch {
+	case math.IsNaN(expected) || math.IsNaN(actual):
+		return math.IsNaN(expected) && math.IsNaN(actual)
+	case math.IsInf(expected, 0) || math.IsInf(actual, 0):
+		return expected == actual
+	}
+
+	diff := math.Abs(expected - actual)
+	return diff <= c.AbsTolerance || diff <= c.RelTolerance*math.Abs(expected)
+}
+
+// tokenize splits normalized lines into positioned tokens
+func tokenize(lines []string) []token {
+	var tokens []token
+	for i, line := range lines {
+		for j, field := range strings.Fields(line) {
+			tokens = append(tokens, token{text: field, line: i + 1, pos: j + 1})
+		}
+	}
+	return tokens
+}
+
+// parseNumber parses a decimal or scientific-notation number, including
+// NaN and Inf. Hexadecimal forms are treated as plain words.
+func parseNumber(s string) (float64, bool) {
+	if strings.ContainsAny(s, "xX_") {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		// Out-of-range values parse to +/-Inf or 0, which is still a number
+		var numErr *strconv.NumError
+		if errors.As(err, &numErr) && errors.Is(numErr.Err, strconv.ErrRange) {
+			return v, true
+		}
+		return 0, false
+	}
+	return v, true
+}
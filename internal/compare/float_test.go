@@ -0,0 +1,151 @@
+package compare
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFloatComparator_WithinAbsoluteTolerance(t *testing.T) {
+	c := NewFloatComparator(1e-6)
+
+	result := c.Compare("0.3333333", "0.33333335")
+	if !result.Match {
+		t.Errorf("Expected match within abs tolerance, got: %s", result.Message)
+	}
+}
+
+func TestFloatComparator_WithinRelativeTolerance(t *testing.T) {
+	c := NewFloatComparator(1e-6)
+
+	// Absolute error is 0.5, but relative error is 5e-7
+	result := c.Compare("1000000", "1000000.5")
+	if !result.Match {
+		t.Errorf("Expected match within rel tolerance, got: %s", result.Message)
+	}
+}
+
+func TestFloatComparator_OutsideTolerance(t *testing.T) {
+	c := NewFloatComparator(1e-6)
+
+	result := c.Compare("1.5 2.5\n3.5", "1.5 2.5\n3.6")
+	if result.Match {
+		t.Fatal("Expected mismatch")
+	}
+	if result.DiffLine != 2 || result.DiffToken != 1 {
+		t.Errorf("Expected diff at line 2 token 1, got line %d token %d", result.DiffLine, result.DiffToken)
+	}
+	if result.DiffExpected != "3.5" || result.DiffActual != "3.6" {
+		t.Errorf("Expected tokens 3.5/3.6, got %q/%q", result.DiffExpected, result.DiffActual)
+	}
+	if !strings.Contains(result.Message, "abs error 0.1") {
+		t.Errorf("Expected message to report the error size, got: %s", result.Message)
+	}
+}
+
+func TestFloatComparator_ScientificNotation(t *testing.T) {
+	c := NewFloatComparator(1e-9)
+
+	result := c.Compare("0.00012", "1.2e-4")
+	if !result.Match {
+		t.Errorf("Expected match for scientific notation, got: %s", result.Message)
+	}
+
+	result = c.Compare("1.5E+3", "1500")
+	if !result.Match {
+		t.Errorf("Expected match for uppercase exponent, got: %s", result.Message)
+	}
+}
+
+func TestFloatComparator_NaNAndInf(t *testing.T) {
+	c := NewFloatComparator(1e-6)
+
+	if result := c.Compare("nan", "NaN"); !result.Match {
+		t.Error("Expected NaN to match NaN")
+	}
+	if result := c.Compare("inf", "+Inf"); !result.Match {
+		t.Error("Expected inf to match +Inf")
+	}
+	if result := c.Compare("-inf", "inf"); result.Match {
+		t.Error("Expected -inf to mismatch inf")
+	}
+	if result := c.Compare("1.0", "nan"); result.Match {
+		t.Error("Expected number to mismatch NaN")
+	}
+	if result := c.Compare("1e308", "inf"); result.Match {
+		t.Error("Expected finite value to mismatch inf")
+	}
+}
+
+func TestFloatComparator_NonNumericTokens(t *testing.T) {
+	c := NewFloatComparator(1e-6)
+
+	if result := c.Compare("YES 0.5", "YES 0.5000001"); !result.Match {
+		t.Errorf("Expected match, got: %s", result.Message)
+	}
+
+	result := c.Compare("YES 0.5", "yes 0.5")
+	if result.Match {
+		t.Fatal("Expected mismatch for differing words")
+	}
+	if result.DiffToken != 1 {
+		t.Errorf("Expected diff at token 1, got %d", result.DiffToken)
+	}
+
+	if result := c.Compare("0.5", "half"); result.Match {
+		t.Error("Expected mismatch when a number is replaced by a word")
+	}
+}
+
+func TestFloatComparator_TokenCount(t *testing.T) {
+	c := NewFloatComparator(1e-6)
+
+	result := c.Compare("1.0 2.0 3.0", "1.0 2.0")
+	if result.Match {
+		t.Fatal("Expected mismatch for missing token")
+	}
+	if !strings.Contains(result.Message, "missing token") {
+		t.Errorf("Expected missing token message, got: %s", result.Message)
+	}
+
+	result = c.Compare("1.0", "1.0 2.0")
+	if result.Match {
+		t.Fatal("Expected mismatch for extra token")
+	}
+	if !strings.Contains(result.Message, "extra token") {
+		t.Errorf("Expected extra token message, got: %s", result.Message)
+	}
+}
+
+func TestFloatComparator_IgnoresLayout(t *testing.T) {
+	c := NewFloatComparator(1e-6)
+
+	result := c.Compare("1.0 2.0\n3.0\n", "  1.0   2.0\r\n3.0")
+	if !result.Match {
+		t.Errorf("Expected match ignoring whitespace, got: %s", result.Message)
+	}
+}
+
+func TestFloatComparator_DefaultTolerance(t *testing.T) {
+	c := NewFloatComparator(0)
+	if c.AbsTolerance != DefaultFloatTolerance || c.RelTolerance != DefaultFloatTolerance {
+		t.Errorf("Expected default tolerance %g, got abs=%g rel=%g",
+			DefaultFloatTolerance, c.AbsTolerance, c.RelTolerance)
+	}
+}
+
+func TestFloatComparator_Registry(t *testing.T) {
+	c, err := New("float", Options{FloatTolerance: 1e-3})
+	if err != nil {
+		t.Fatalf("New(float) returned error: %v", err)
+	}
+	if c.Name() != "float" {
+		t.Errorf("Expected name 'float', got '%s'", c.Name())
+	}
+	if result := c.Compare("1.000", "1.0009"); !result.Match {
+		t.Errorf("Expected match with tolerance 1e-3, got: %s", result.Message)
+	}
+
+	if _, err := New("float", Options{FloatTolerance: -1}); err == nil {
+		t.Error("Expected error for negative tolerance")
+	}
+}
@@ -0,0 +1,96 @@
+package compare
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Options configures comparators created through the registry.
+// Each comparator reads only the options that apply to it.
+type Options struct {
+	// FloatTolerance is the allowed absolute or relative error for numeric tokens
+	FloatTolerance float64
+
+	// UnorderedTokens also ignores token order within each line for unordered comparison
+	UnorderedTokens bool
+}
+
+// Factory creates a comparator configured with the given options.
+type Factory func(opts Options) (Comparator, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Factory)
+)
+
+func init() {
+	Register("default", func(Options) (Comparator, error) {
+		return NewDefaultComparator(), nil
+	})
+	Register("strict", func(Options) (Comparator, error) {
+		return NewStrictComparator(), nil
+	})
+	Register("float", func(opts Options) (Comparator, error) {
+		if opts.FloatTolerance < 0 {
+			return nil, fmt.Errorf("float tolerance must not be negative: %g", opts.FloatTolerance)
+		}
+		return NewFloatComparator(opts.FloatTolerance), nil
+	})
+	Register("unordered", func(opts Options) (Comparator, error) {
+		return NewUnorderedComparator(opts.UnorderedTokens), nil
+	})
+}
+
+// Register makes a comparator available under the given mode name.
+// It panics if the name is empty or already registered.
+func Register(mode string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if mode == "" || factory == nil {
+		panic("compare: Register called with empty mode or nil factory")
+	}
+	if _, exists := registry[mode]; exists {
+		panic("compare: Register called twice for mode " + mode)
+	}
+	registry[mode] = factory
+}
+
+// New creates the comparator registered under mode.
+// An empty mode selects the default comparator.
+func New(mode string, opts Options) (Comparator, error) {
+	if mode == "" {
+		mode = "default"
+	}
+
+	registryMu.RLock()
+	factory, ok := registry[mode]
+	registryMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown comparison mode: %s", mode)
+	}
+	return factory(opts)
+}
+
+// Registered reports whether a comparator is registered under mode.
+func Registered(mode string) bool {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	_, ok := registry[mode]
+	return ok
+}
+
+// Modes returns the names of all registered comparators in sorted order.
+func Modes() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	modes := make([]string, 0, len(registry))
+	for mode := range registry {
+		modes = append(modes, mode)
+	}
+	sort.Strings(modes)
+	return modes
+}
@@ -0,0 +1,65 @@
+package compare
+
+import (
+	"testing"
+)
+
+func TestNew_BuiltinModes(t *testing.T) {
+	for _, mode := range []string{"default", "strict"} {
+		c, err := New(mode, Options{})
+		if err != nil {
+			t.Fatalf("New(%q) returned error: %v", mode, err)
+		}
+		if c.Name() != mode {
+			t.Errorf("New(%q) returned comparator named %q", mode, c.Name())
+		}
+	}
+}
+
+func TestNew_EmptyModeIsDefault(t *testing.T) {
+	c, err := New("", Options{})
+	if err != nil {
+		t.Fatalf("New(\"\") returned error: %v", err)
+	}
+	if c.Name() != "default" {
+		t.Errorf("Expected default comparator, got '%s'", c.Name())
+	}
+}
+
+func TestNew_UnknownMode(t *testing.T) {
+	if _, err := New("fuzzy", Options{}); err == nil {
+		t.Error("Expected error for unknown mode")
+	}
+	if Registered("fuzzy") {
+		t.Error("Expected 'fuzzy' to be unregistered")
+	}
+}
+
+func TestRegister(t *testing.T) {
+	Register("test-always-match", func(Options) (Comparator, error) {
+		return NewDefaultComparator(), nil
+	})
+
+	if !Registered("test-always-match") {
+		t.Fatal("Expected registered mode")
+	}
+
+	found := false
+	for _, mode := range Modes() {
+		if mode == "test-always-match" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Modes() = %v, missing registered mode", Modes())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic on duplicate registration")
+		}
+	}()
+	Register("test-always-match", func(Options) (Comparator, error) {
+		return NewDefaultComparator(), nil
+	})
+}
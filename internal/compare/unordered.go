@@ -0,0 +1,125 @@
+package compare
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxListedElements caps how many missing/extra elements appear in Message
+const maxListedElements = 5
+
+// UnorderedComparator compares outputs as multisets of lines
+// - Runs of whitespace inside a line are collapsed, blank lines are ignored
+// - Line order does not matter, but duplicate lines must appear as often
+// - With Tokens set, token order within each line is ignored as well
+type UnorderedComparator struct {
+	// Tokens makes each line an unordered multiset of tokens
+	Tokens bool
+}
+
+// NewUnorderedComparator creates a new UnorderedComparator
+func NewUnorderedComparator(tokens bool) *UnorderedComparator {
+	return &UnorderedComparator{Tokens: tokens}
+}
+
+// Name returns the comparator's identifier
+func (c *UnorderedComparator) Name() string {
+	return "unordered"
+}
+
+// Compare checks that both outputs contain the same lines in any order
+func (c *UnorderedComparator) Compare(expected, actual string) Result {
+	expectedLines := normalizeLines(expected)
+	actualLines := normalizeLines(actual)
+
+	result := Result{
+		Match:    true,
+		Expected: strings.Join(expectedLines, "\n"),
+		Actual:   strings.Join(actualLines, "\n"),
+	}
+
+	// Count each canonical actual line
+	remaining := make(map[string]int)
+	for _, line := range actualLines {
+		if key := c.canonical(line); key != "" {
+			remaining[key]++
+		}
+	}
+
+	// Consume expected lines, recording those the actual output lacks
+	for _, line := range expectedLines {
+		key := c.canonical(line)
+		if key == "" {
+			continue
+		}
+		if remaining[key] > 0 {
+			remaining[key]--
+		} else {
+			result.Missing = append(result.Missing, key)
+		}
+	}
+
+	// Whatever is left over was not expected (reported in output order)
+	for _, line := range actualLines {
+		key := c.canonical(line)
+		if key != "" && remaining[key] > 0 {
+			remaining[key]--
+			result.Extra = append(result.Extra, key)
+		}
+	}
+
+	if len(result.Missing) == 0 && len(result.Extra) == 0 {
+		return result
+	}
+
+	result.Match = false
+	result.Message = describeMultisetDiff(result.Missing, result.Extra)
+	if len(result.Missing) > 0 {
+		result.DiffExpected = result.Missing[0]
+	}
+	if len(result.Extra) > 0 {
+		result.DiffActual = result.Extra[0]
+	}
+	return result
+}
+
+// canonical returns the comparison key for a normalized line
+func (c *UnorderedComparator) canonical(line string) string {
+	fields := strings.Fields(line)
+	if c.Tokens {
+		sort.Strings(fields)
+	}
+	return strings.Join(fields, " ")
+}
+
+// describeMultisetDiff summarizes missing and extra elements for display
+func describeMultisetDiff(missing, extra []string) string {
+	var parts []string
+	if len(missing) > 0 {
+		parts = append(parts, fmt.Sprintf("missing %d line(s): %s", len(missing), quoteList(missing)))
+	}
+	if len(extra) > 0 {
+		parts = append(parts, fmt.Sprintf("unexpected %d line(s): %s", len(extra), quoteList(extra)))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// quoteList renders up to maxListedElements quoted elements
+func quoteList(elems []string) string {
+	shown := elems
+	if len(shown) > maxListedElements {
+		shown = shown[:maxListedElements]
+	}
+
+	quoted := make([]string, len(shown))
+	for i, e := range shown {
+		quoted[i] = fmt.Sprintf("%q", e)
+	}
+
+	s := strings.Join(quoted, ", ")
+	if len(elems) > len(shown) {
+		s += fmt.Sprintf(" (+%d more)", len(elems)-len(shown))
+	}
+	return s
+}
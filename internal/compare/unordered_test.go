@@ -0,0 +1,112 @@
+package compare
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUnorderedComparator_LineOrder(t *testing.T) {
+	c := NewUnorderedComparator(false)
+
+	result := c.Compare("a\nb\nc", "c\na\nb\n")
+	if !result.Match {
+		t.Errorf("Expected match for reordered lines, got: %s", result.Message)
+	}
+}
+
+func TestUnorderedComparator_TokenOrderMatters(t *testing.T) {
+	c := NewUnorderedComparator(false)
+
+	result := c.Compare("0 1", "1 0")
+	if result.Match {
+		t.Error("Expected mismatch for reordered tokens without Tokens option")
+	}
+}
+
+func TestUnorderedComparator_UnorderedTokens(t *testing.T) {
+	c := NewUnorderedComparator(true)
+
+	result := c.Compare("0 1", "1 0")
+	if !result.Match {
+		t.Errorf("Expected match for reordered tokens, got: %s", result.Message)
+	}
+
+	result = c.Compare("1 2\n3 4", "4 3\n2 1")
+	if !result.Match {
+		t.Errorf("Expected match for reordered lines and tokens, got: %s", result.Message)
+	}
+
+	result = c.Compare("1 2", "1 2 2")
+	if result.Match {
+		t.Error("Expected mismatch when a line has an extra token")
+	}
+}
+
+func TestUnorderedComparator_Duplicates(t *testing.T) {
+	c := NewUnorderedComparator(false)
+
+	result := c.Compare("x\nx\ny", "x\ny\ny")
+	if result.Match {
+		t.Fatal("Expected mismatch for different duplicate counts")
+	}
+	if !reflect.DeepEqual(result.Missing, []string{"x"}) {
+		t.Errorf("Expected Missing=[x], got %v", result.Missing)
+	}
+	if !reflect.DeepEqual(result.Extra, []string{"y"}) {
+		t.Errorf("Expected Extra=[y], got %v", result.Extra)
+	}
+}
+
+func TestUnorderedComparator_MissingAndExtra(t *testing.T) {
+	c := NewUnorderedComparator(false)
+
+	result := c.Compare("a\nb\nc", "d\nb")
+	if result.Match {
+		t.Fatal("Expected mismatch")
+	}
+	if !reflect.DeepEqual(result.Missing, []string{"a", "c"}) {
+		t.Errorf("Expected Missing=[a c], got %v", result.Missing)
+	}
+	if !reflect.DeepEqual(result.Extra, []string{"d"}) {
+		t.Errorf("Expected Extra=[d], got %v", result.Extra)
+	}
+	if result.DiffLine != 0 {
+		t.Errorf("Expected no diff line for unordered comparison, got %d", result.DiffLine)
+	}
+	if !strings.Contains(result.Message, `missing 2 line(s): "a", "c"`) ||
+		!strings.Contains(result.Message, `unexpected 1 line(s): "d"`) {
+		t.Errorf("Unexpected message: %s", result.Message)
+	}
+}
+
+func TestUnorderedComparator_Whitespace(t *testing.T) {
+	c := NewUnorderedComparator(false)
+
+	result := c.Compare("a b\n\nc\n", "  c\r\na   b\n\n\n")
+	if !result.Match {
+		t.Errorf("Expected match ignoring whitespace and blank lines, got: %s", result.Message)
+	}
+}
+
+func TestUnorderedComparator_MessageTruncation(t *testing.T) {
+	c := NewUnorderedComparator(false)
+
+	result := c.Compare("1\n2\n3\n4\n5\n6\n7", "")
+	if !strings.Contains(result.Message, "(+2 more)") {
+		t.Errorf("Expected truncated message, got: %s", result.Message)
+	}
+}
+
+func TestUnorderedComparator_Registry(t *testing.T) {
+	c, err := New("unordered", Options{UnorderedTokens: true})
+	if err != nil {
+		t.Fatalf("New(unordered) returned error: %v", err)
+	}
+	if c.Name() != "unordered" {
+		t.Errorf("Expected name 'unordered', got '%s'", c.Name())
+	}
+	if result := c.Compare("0 1", "1 0"); !result.Match {
+		t.Error("Expected UnorderedTokens option to be applied")
+	}
+}
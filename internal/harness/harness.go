@@ -0,0 +1,74 @@
+// Package harness generates per-language driver code for function-signature
+// problems: the driver parses the test input, calls the solution's function
+// and prints the return value, so solutions never touch stdin.
+package harness
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/marv972228/sandbox_judge/internal/problem"
+)
+
+// Harness adapts one language's solutions to function signatures.
+type Harness interface {
+	// Wrap returns a complete program: the solution's source plus a driver
+	// that reads one JSON argument per input line, calls the function and
+	// prints the result as compact JSON
+	Wrap(sig *problem.Signature, source string) string
+
+	// Stub returns a starter solution declaring the function
+	Stub(sig *problem.Signature) string
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Harness)
+)
+
+func init() {
+	Register("python", pythonHarness{})
+}
+
+// Register makes a harness available for the given language identifier.
+// It panics if the language is empty or already registered.
+func Register(language string, h Harness) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if language == "" || h == nil {
+		panic("harness: Register called with empty language or nil harness")
+	}
+	if _, exists := registry[language]; exists {
+		panic("harness: Register called twice for language " + language)
+	}
+	registry[language] = h
+}
+
+// For returns the harness registered for a language.
+func For(language string) (Harness, error) {
+	registryMu.RLock()
+	h, ok := registry[language]
+	registryMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("function-signature problems are not supported for %s (available: %s)",
+			language, strings.Join(Languages(), ", "))
+	}
+	return h, nil
+}
+
+// Languages returns the languages with a harness, sorted by name.
+func Languages() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	languages := make([]string, 0, len(registry))
+	for language := range registry {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
@@ -0,0 +1,26 @@
+package harness
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFor(t *testing.T) {
+	if _, err := For("python"); err != nil {
+		t.Errorf("For(python) returned error: %v", err)
+	}
+
+	_, err := For("cobol")
+	if err == nil || !strings.Contains(err.Error(), "available: python") {
+		t.Errorf("For(cobol) error = %v, want the available languages", err)
+	}
+}
+
+func TestRegister_Duplicate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Register did not panic for a duplicate language")
+		}
+	}()
+	Register("python", pythonHarness{})
+}
@@ -0,0 +1,202 @@
+package harness
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/marv972228/sandbox_judge/internal/problem"
+)
+
+// pythonHarness supports solutions that define `class Solution` with the
+// function as a method (LeetCode style) or the function at top level
+type pythonHarness struct{}
+
+// pythonPrelude comes before the solution so that its annotations can use
+// the typing names, ListNode and TreeNode
+const pythonPrelude = `# --- judge harness: prelude ---
+import json as _json
+import sys as _sys
+from typing import *
+
+
+class ListNode:
+    def __init__(self, val=0, next=None):
+        self.val = val
+        self.next = next
+
+
+class TreeNode:
+    def __init__(self, val=0, left=None, right=None):
+        self.val = val
+        self.left = left
+        self.right = right
+
+
+# --- solution ---
+`
+
+// pythonDriver converts between JSON and Python values by signature type
+// and calls the solution; the constants it needs are appended after it
+const pythonDriver = `
+# --- judge harness: driver ---
+def _decode(t, v):
+    if t == "list":
+        head = tail = ListNode()
+        for x in v:
+            tail.next = ListNode(x)
+            tail = tail.next
+        return head.next
+    if t == "tree":
+        if not v or v[0] is None:
+            return None
+        root = TreeNode(v[0])
+        queue, i, k = [root], 0, 1
+        while k < len(v):
+            node = queue[i]
+            i += 1
+            if v[k] is not None:
+                node.left = TreeNode(v[k])
+                queue.append(node.left)
+            k += 1
+            if k < len(v) and v[k] is not None:
+                node.right = TreeNode(v[k])
+                queue.append(node.right)
+            k += 1
+        return root
+    if t.endswith("[]"):
+        return [_decode(t[:-2], x) for x in v]
+    if t == "float":
+        return float(v)
+    return v
+
+
+def _encode(t, v):
+    if t == "list":
+        out = []
+        while v is not None:
+            out.append(v.val)
+            v = v.next
+        return out
+    if t == "tree":
+        out, queue, i = [], [v], 0
+        while i < len(queue):
+            node = queue[i]
+            i += 1
+            if node is None:
+                out.append(None)
+                continue
+            out.append(node.val)
+            queue.append(node.left)
+            queue.append(node.right)
+        while out and out[-1] is None:
+            out.pop()
+        return out
+    if t.endswith("[]"):
+        return [_encode(t[:-2], x) for x in v]
+    if t == "float":
+        return float(v)
+    return v
+
+
+def _main():
+    lines = [line for line in _sys.stdin.read().splitlines() if line.strip()]
+    if len(lines) != len(_PARAMS):
+        _sys.exit("harness: expected %d input lines, got %d" % (len(_PARAMS), len(lines)))
+    args = [_decode(t, _json.loads(line)) for t, line in zip(_PARAMS, lines)]
+
+    if "Solution" in globals():
+        fn = getattr(Solution(), _FUNCTION, None)
+    else:
+        fn = globals().get(_FUNCTION)
+    if fn is None:
+        _sys.exit("harness: the solution does not define %s" % _FUNCTION)
+
+    result = fn(*args)
+    print(_json.dumps(_encode(_RETURNS, result), separators=(",", ":")))
+
+`
+
+// Wrap implements Harness
+func (pythonHarness) Wrap(sig *problem.Signature, source string) string {
+	params := make([]string, len(sig.Params))
+	for i, p := range sig.Params {
+		params[i] = fmt.Sprintf("%q", p.Type)
+	}
+
+	var b strings.Builder
+	b.WriteString(pythonPrelude)
+	b.WriteString(source)
+	if !strings.HasSuffix(source, "\n") {
+		b.WriteString("\n")
+	}
+	b.WriteString(pythonDriver)
+	fmt.Fprintf(&b, "_FUNCTION = %q\n", sig.Function)
+	fmt.Fprintf(&b, "_PARAMS = [%s]\n", strings.Join(params, ", "))
+	fmt.Fprintf(&b, "_RETURNS = %q\n", sig.Returns)
+	b.WriteString("_main()\n")
+	return b.String()
+}
+
+// Stub implements Harness
+func (pythonHarness) Stub(sig *problem.Signature) string {
+	var b strings.Builder
+	b.WriteString("from typing import List, Optional\n\n")
+
+	if usesType(sig, problem.TypeList) {
+		b.WriteString(`# The judge provides ListNode:
+# class ListNode:
+#     def __init__(self, val=0, next=None):
+#         self.val = val
+#         self.next = next
+
+`)
+	}
+	if usesType(sig, problem.TypeTree) {
+		b.WriteString(`# The judge provides TreeNode:
+# class TreeNode:
+#     def __init__(self, val=0, left=None, right=None):
+#         self.val = val
+#         self.left = left
+#         self.right = right
+
+`)
+	}
+
+	params := []string{"self"}
+	for _, p := range sig.Params {
+		params = append(params, fmt.Sprintf("%s: %s", p.Name, pythonType(p.Type)))
+	}
+	b.WriteString("\nclass Solution:\n")
+	fmt.Fprintf(&b, "    def %s(%s) -> %s:\n", sig.Function, strings.Join(params, ", "), pythonType(sig.Returns))
+	b.WriteString("        pass\n")
+	return b.String()
+}
+
+// pythonType returns the type annotation for a signature type
+func pythonType(t problem.Type) string {
+	if elem, ok := t.Elem(); ok {
+		return "List[" + pythonType(elem) + "]"
+	}
+	switch t {
+	case problem.TypeString:
+		return "str"
+	case problem.TypeList:
+		return "Optional[ListNode]"
+	case problem.TypeTree:
+		return "Optional[TreeNode]"
+	}
+	return string(t)
+}
+
+// usesType reports whether a parameter or the return value has type t
+func usesType(sig *problem.Signature, t problem.Type) bool {
+	if sig.Returns == t {
+		return true
+	}
+	for _, p := range sig.Params {
+		if p.Type == t {
+			return true
+		}
+	}
+	return false
+}
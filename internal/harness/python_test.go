@@ -0,0 +1,142 @@
+package harness
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/marv972228/sandbox_judge/internal/problem"
+)
+
+// runPython runs a program with python3 on the host, skipping the test if
+// python3 is not installed
+func runPython(t *testing.T, program, stdin string) (string, error) {
+	t.Helper()
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skip("python3 not installed")
+	}
+
+	path := filepath.Join(t.TempDir(), "main.py")
+	if err := os.WriteFile(path, []byte(program), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command("python3", path)
+	cmd.Stdin = strings.NewReader(stdin)
+	out, err := cmd.CombinedOutput()
+	return string(out), err
+}
+
+func TestPython_Wrap(t *testing.T) {
+	tests := []struct {
+		name   string
+		sig    problem.Signature
+		source string
+		input  string
+		want   string
+	}{
+		{
+			name: "method",
+			sig: problem.Signature{Function: "twoSum", Returns: "int[]", Params: []problem.Param{
+				{Name: "nums", Type: "int[]"}, {Name: "target", Type: "int"},
+			}},
+			source: `class Solution:
+    def twoSum(self, nums: List[int], target: int) -> List[int]:
+        seen = {}
+        for i, x in enumerate(nums):
+            if target - x in seen:
+                return [seen[target - x], i]
+            seen[x] = i
+`,
+			input: "[2,7,11,15]\n9\n",
+			want:  "[0,1]\n",
+		},
+		{
+			name:   "top-level function with strings and bools",
+			sig:    problem.Signature{Function: "check", Returns: "bool", Params: []problem.Param{{Name: "s", Type: "string"}}},
+			source: "def check(s):\n    return s == s[::-1]",
+			input:  "\"abba\"\n",
+			want:   "true\n",
+		},
+		{
+			name:   "matrix and float",
+			sig:    problem.Signature{Function: "mean", Returns: "float", Params: []problem.Param{{Name: "grid", Type: "int[][]"}}},
+			source: "def mean(grid):\n    cells = [x for row in grid for x in row]\n    return sum(cells) // len(cells)\n",
+			input:  "[[1,2],[3,4]]\n",
+			want:   "2.0\n",
+		},
+		{
+			name: "linked list",
+			sig:  problem.Signature{Function: "reverseList", Returns: "list", Params: []problem.Param{{Name: "head", Type: "list"}}},
+			source: `class Solution:
+    def reverseList(self, head: Optional[ListNode]) -> Optional[ListNode]:
+        prev = None
+        while head:
+            head.next, prev, head = prev, head, head.next
+        return prev
+`,
+			input: "[1,2,3]\n",
+			want:  "[3,2,1]\n",
+		},
+		{
+			name: "tree",
+			sig:  problem.Signature{Function: "invertTree", Returns: "tree", Params: []problem.Param{{Name: "root", Type: "tree"}}},
+			source: `def invertTree(root):
+    if root:
+        root.left, root.right = invertTree(root.right), invertTree(root.left)
+    return root
+`,
+			input: "[1,2,null,3]\n",
+			want:  "[1,null,2,null,3]\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program := pythonHarness{}.Wrap(&tt.sig, tt.source)
+			got, err := runPython(t, program, tt.input)
+			if err != nil {
+				t.Fatalf("program failed: %v\n%s", err, got)
+			}
+			if got != tt.want {
+				t.Errorf("output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPython_WrapErrors(t *testing.T) {
+	sig := &problem.Signature{Function: "f", Returns: "int", Params: []problem.Param{{Name: "a", Type: "int"}}}
+
+	out, err := runPython(t, pythonHarness{}.Wrap(sig, "def f(a):\n    return a\n"), "1\n2\n")
+	if err == nil || !strings.Contains(out, "expected 1 input lines, got 2") {
+		t.Errorf("extra input line: err = %v, output %q", err, out)
+	}
+
+	out, err = runPython(t, pythonHarness{}.Wrap(sig, "def g(a):\n    return a\n"), "1\n")
+	if err == nil || !strings.Contains(out, "does not define f") {
+		t.Errorf("missing function: err = %v, output %q", err, out)
+	}
+}
+
+func TestPython_Stub(t *testing.T) {
+	sig := &problem.Signature{Function: "merge", Returns: "list", Params: []problem.Param{
+		{Name: "lists", Type: "int[][]"}, {Name: "head", Type: "list"}, {Name: "name", Type: "string"},
+	}}
+	stub := pythonHarness{}.Stub(sig)
+
+	want := "    def merge(self, lists: List[List[int]], head: Optional[ListNode], name: str) -> Optional[ListNode]:\n"
+	if !strings.Contains(stub, want) {
+		t.Errorf("stub missing signature %q:\n%s", want, stub)
+	}
+	if !strings.Contains(stub, "# class ListNode:") || strings.Contains(stub, "TreeNode") {
+		t.Errorf("stub should document ListNode only:\n%s", stub)
+	}
+
+	// The stub must run under the harness as is
+	out, err := runPython(t, pythonHarness{}.Wrap(sig, stub), "[[1]]\n[1,2]\n\"x\"\n")
+	if err != nil || out != "[]\n" {
+		t.Errorf("wrapped stub: err = %v, output %q", err, out)
+	}
+}
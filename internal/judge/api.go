@@ -0,0 +1,61 @@
+package judge
+
+import "github.com/marv972228/sandbox_judge/pkg/api"
+
+// API converts the result to its public wire format
+func (r *Result) API() *api.Result {
+	out := &api.Result{
+		SchemaVersion: api.SchemaVersion,
+		ProblemID:     r.ProblemID,
+		Language:      r.Language,
+		Verdict:       api.Verdict(r.FinalVerdict),
+		Passed:        r.Passed,
+		Skipped:       r.Skipped,
+		Total:         r.Total,
+		TimeMS:        milliseconds(r.TotalDuration),
+		TimeLimitMS:   r.TimeLimit.Milliseconds(),
+		Score:         r.Score,
+		MaxScore:      r.MaxScore,
+		Tests:         make([]api.TestResult, len(r.TestResults)),
+	}
+
+	for i := range r.TestResults {
+		out.Tests[i] = r.TestResults[i].API()
+	}
+	for _, st := range r.Subtasks {
+		out.Subtasks = append(out.Subtasks, api.SubtaskResult{
+			Name:       st.Name,
+			Score:      st.Score,
+			Points:     st.Points,
+			Passed:     st.Passed,
+			Total:      st.Total,
+			BlockedBy:  st.BlockedBy,
+			Incomplete: st.Incomplete,
+		})
+	}
+	return out
+}
+
+// API converts the test result to its public wire format. Input and outputs
+// are only kept for failed tests, where they explain the verdict.
+func (tr *TestResult) API() api.TestResult {
+	out := api.TestResult{
+		Name:        tr.TestCase.Name,
+		Description: tr.TestCase.Meta.Description,
+		Sample:      tr.TestCase.Sample,
+		Hidden:      tr.TestCase.Meta.Hidden,
+		Verdict:     api.Verdict(tr.Verdict),
+		Score:       tr.Score,
+		TimeMS:      milliseconds(tr.Duration),
+		TimeLimitMS: tr.TimeLimit.Milliseconds(),
+		MemoryBytes: tr.Memory,
+		Message:     tr.Message,
+		Error:       tr.Error,
+	}
+	if isFailure(tr.Verdict) {
+		out.Input = tr.TestCase.Input
+		out.Expected = tr.Expected
+		out.Actual = tr.Actual
+	}
+	return out
+}
@@ -0,0 +1,65 @@
+package judge
+
+import (
+	"testing"
+	"time"
+
+	"github.com/marv972228/sandbox_judge/internal/problem"
+	"github.com/marv972228/sandbox_judge/internal/runner"
+	"github.com/marv972228/sandbox_judge/pkg/api"
+)
+
+func TestResultAPI(t *testing.T) {
+	r := &Result{
+		ProblemID:     "p",
+		Language:      "python",
+		FinalVerdict:  runner.VerdictWrongAnswer,
+		TotalDuration: 1500 * time.Microsecond,
+		Passed:        1,
+		Total:         2,
+		TimeLimit:     3 * time.Second,
+		TestResults: []TestResult{
+			{
+				TestCase:  problem.TestCase{Name: "sample/1", Input: "1\n", Expected: "1\n", Sample: true},
+				Verdict:   runner.VerdictAccepted,
+				Duration:  500 * time.Microsecond,
+				Expected:  "1\n",
+				Actual:    "1\n",
+				Score:     1,
+				TimeLimit: 3 * time.Second,
+			},
+			{
+				TestCase:  problem.TestCase{Name: "hidden/1", Input: "2\n", Expected: "2\n"},
+				Verdict:   runner.VerdictWrongAnswer,
+				Duration:  time.Millisecond,
+				Expected:  "2\n",
+				Actual:    "3\n",
+				Message:   "line 1 differs",
+				TimeLimit: 3 * time.Second,
+				Memory:    4096,
+			},
+		},
+		Subtasks: []SubtaskResult{{Name: "all", Score: 0, Points: 100, Passed: 1, Total: 2}},
+	}
+
+	got := r.API()
+
+	if got.SchemaVersion != api.SchemaVersion || got.Verdict != api.VerdictWrongAnswer {
+		t.Errorf("Unexpected header: %+v", got)
+	}
+	if got.TimeMS != 1.5 || got.TimeLimitMS != 3000 {
+		t.Errorf("TimeMS = %v, TimeLimitMS = %d; want 1.5, 3000", got.TimeMS, got.TimeLimitMS)
+	}
+	if len(got.Subtasks) != 1 || got.Subtasks[0].Points != 100 {
+		t.Errorf("Unexpected subtasks: %+v", got.Subtasks)
+	}
+
+	// Outputs are kept only where they explain a failure
+	passed, failed := got.Tests[0], got.Tests[1]
+	if passed.Input != "" || passed.Actual != "" || !passed.Sample || passed.TimeMS != 0.5 {
+		t.Errorf("Unexpected passing test: %+v", passed)
+	}
+	if failed.Input != "2\n" || failed.Expected != "2\n" || failed.Actual != "3\n" || failed.MemoryBytes != 4096 {
+		t.Errorf("Unexpected failing test: %+v", failed)
+	}
+}
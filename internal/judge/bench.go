@@ -0,0 +1,126 @@
+package judge
+
+import (
+	"context"
+	"time"
+
+	"github.com/marv972228/sandbox_judge/internal/problem"
+	"github.com/marv972228/sandbox_judge/internal/runner"
+	"github.com/marv972228/sandbox_judge/internal/stats"
+)
+
+// DefaultBenchRepeat is the number of runs per test when none is given
+const DefaultBenchRepeat = 10
+
+// BenchConfig configures a benchmark
+type BenchConfig struct {
+	// Repeat is the number of times each test case is run (0 = DefaultBenchRepeat)
+	Repeat int
+}
+
+// BenchTest holds the timings of one test case
+type BenchTest struct {
+	// TestCase is the test case that was run
+	TestCase problem.TestCase
+
+	// Verdict is the first non-AC verdict across runs, or AC
+	Verdict runner.Verdict
+
+	// Times are the run durations in milliseconds, in run order
+	Times []float64
+
+	// Time summarizes Times
+	Time stats.Summary
+
+	// PeakMemory is the highest memory usage across runs in bytes (0 if not measured)
+	PeakMemory int64
+}
+
+// BenchResult holds the outcome of benchmarking a solution
+type BenchResult struct {
+	// ProblemID is the problem that was benchmarked
+	ProblemID string
+
+	// Language is the detected language of the solution
+	Language string
+
+	// Repeat is the number of runs per test case
+	Repeat int
+
+	// Tests holds per-test timings in test order
+	Tests []BenchTest
+
+	// Totals are the summed durations of all tests per repetition, in milliseconds
+	Totals []float64
+
+	// Total summarizes Totals
+	Total stats.Summary
+
+	// PeakMemory is the highest memory usage of any run in bytes
+	PeakMemory int64
+}
+
+// Bench runs every test case of a problem Repeat times and summarizes the
+// timings. Runs are sequential so that tests do not compete for the CPU,
+// and repetitions are interleaved so that slow drift (thermal throttling,
+// background load) affects every test alike.
+func (j *Judge) Bench(ctx context.Context, problemID, solutionPath string, cfg BenchConfig) (*BenchResult, error) {
+	sub, testCases, err := j.prepare(ctx, problemID, solutionPath, Selection{})
+	if err != nil {
+		return nil, err
+	}
+	sub.sampleMemory = true
+
+	repeat := cfg.Repeat
+	if repeat <= 0 {
+		repeat = DefaultBenchRepeat
+	}
+
+	result := &BenchResult{
+		ProblemID: problemID,
+		Language:  sub.language,
+		Repeat:    repeat,
+		Tests:     make([]BenchTest, len(testCases)),
+		Totals:    make([]float64, repeat),
+	}
+	for i, tc := range testCases {
+		result.Tests[i] = BenchTest{TestCase: tc, Verdict: runner.VerdictAccepted}
+	}
+
+	for rep := 0; rep < repeat; rep++ {
+		for i, tc := range testCases {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			tr := j.runTestCase(ctx, sub, tc)
+			bt := &result.Tests[i]
+
+			ms := milliseconds(tr.Duration)
+			bt.Times = append(bt.Times, ms)
+			result.Totals[rep] += ms
+
+			if tr.Verdict != runner.VerdictAccepted && bt.Verdict == runner.VerdictAccepted {
+				bt.Verdict = tr.Verdict
+			}
+			if tr.Memory > bt.PeakMemory {
+				bt.PeakMemory = tr.Memory
+			}
+			if tr.Memory > result.PeakMemory {
+				result.PeakMemory = tr.Memory
+			}
+		}
+	}
+
+	for i := range result.Tests {
+		result.Tests[i].Time = stats.Summarize(result.Tests[i].Times)
+	}
+	result.Total = stats.Summarize(result.Totals)
+
+	return result, nil
+}
+
+// milliseconds converts a duration to fractional milliseconds
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,55 @@
+package judge
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/marv972228/sandbox_judge/internal/runner"
+)
+
+func TestBench(t *testing.T) {
+	names := []string{"sample/1", "hidden/1"}
+	loader := writeProblem(t, "p", "id: p\n", echoTests(names, "hidden/1"))
+
+	// Each run takes one millisecond longer than the previous one
+	var calls atomic.Int64
+	fr := &fakeRunner{run: func(cfg runner.RunConfig) *runner.RunResult {
+		n := calls.Add(1)
+		r := echoRunner(cfg)
+		r.Duration = time.Duration(n) * time.Millisecond
+		r.MemoryUsed = n * 1024
+		return r
+	}}
+	j := &Judge{problemLoader: loader, runner: fr}
+
+	result, err := j.Bench(context.Background(), "p", "solution.py", BenchConfig{Repeat: 3})
+	if err != nil {
+		t.Fatalf("Bench returned error: %v", err)
+	}
+
+	if len(fr.configs) != 6 {
+		t.Fatalf("runner called %d times, want 6", len(fr.configs))
+	}
+	for _, cfg := range fr.configs {
+		if !cfg.SampleMemory {
+			t.Fatal("bench runs must sample memory")
+		}
+	}
+
+	// Repetitions interleave: sample/1 gets runs 1, 3, 5 and hidden/1 gets 2, 4, 6
+	sample, hidden := result.Tests[0], result.Tests[1]
+	if sample.Time.Min != 1 || sample.Time.Median != 3 || sample.Time.Max != 5 {
+		t.Errorf("sample/1 times = %+v, want 1..5 ms", sample.Time)
+	}
+	if sample.Verdict != runner.VerdictAccepted || hidden.Verdict != runner.VerdictWrongAnswer {
+		t.Errorf("verdicts = %s, %s; want AC, WA", sample.Verdict, hidden.Verdict)
+	}
+	if hidden.PeakMemory != 6*1024 || result.PeakMemory != 6*1024 {
+		t.Errorf("peak memory = %d, %d; want %d", hidden.PeakMemory, result.PeakMemory, 6*1024)
+	}
+	if want := []float64{3, 7, 11}; result.Totals[0] != want[0] || result.Totals[1] != want[1] || result.Totals[2] != want[2] {
+		t.Errorf("Totals = %v, want %v", result.Totals, want)
+	}
+}
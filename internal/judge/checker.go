@@ -0,0 +1,157 @@
+package judge
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/marv972228/sandbox_judge/internal/compare"
+	"github.com/marv972228/sandbox_judge/internal/runner"
+)
+
+// Exit codes of the testlib checker protocol
+const (
+	checkerExitOK   = 0 // Accepted
+	checkerExitWA   = 1 // Wrong answer
+	checkerExitPE   = 2 // Presentation error
+	checkerExitFail = 3 // Checker itself failed (bad test data, bug)
+	checkerExitPC   = 7 // Partially correct, message starts with the score in [0, 1]
+)
+
+// File names passed to the checker inside runner.FilesDir
+const (
+	checkerInputFile  = "input.txt"
+	checkerOutputFile = "output.txt"
+	checkerAnswerFile = "answer.txt"
+)
+
+// outputChecker judges a solution's output on a test case. Checker programs
+// need the test input and can fail on their own, which the judge reports as
+// SE; registry comparators are adapted with comparatorChecker.
+type outputChecker interface {
+	Check(ctx context.Context, input, expected, actual string) (compare.Result, error)
+}
+
+// comparatorChecker adapts a registry comparator to outputChecker
+type comparatorChecker struct {
+	comparator compare.Comparator
+}
+
+// Check compares the outputs; the input is not needed and comparing cannot fail
+func (c comparatorChecker) Check(_ context.Context, _, expected, actual string) (compare.Result, error) {
+	return c.comparator.Compare(expected, actual), nil
+}
+
+// programChecker runs a problem-supplied checker program in the sandbox.
+// Like testlib checkers it is invoked as `checker <input> <output> <answer>`
+// and reports its verdict through the exit code, with a message on stderr.
+type programChecker struct {
+	runner      runner.Runner
+	sourcePath  string
+	language    string
+	timeLimit   time.Duration
+	memoryLimit int64
+}
+
+// newProgramChecker creates a checker for the program at sourcePath
+func newProgramChecker(r runner.Runner, sourcePath string, timeLimit time.Duration, memoryLimit int64) (*programChecker, error) {
+	ext := filepath.Ext(sourcePath)
+	language := extensionToLanguage(ext)
+	if language == "" {
+		return nil, fmt.Errorf("unsupported checker file extension: %s", ext)
+	}
+
+	return &programChecker{
+		runner:      r,
+		sourcePath:  sourcePath,
+		language:    language,
+		timeLimit:   timeLimit,
+		memoryLimit: memoryLimit,
+	}, nil
+}
+
+// Check runs the checker on the test input, contestant output and reference answer
+func (c *programChecker) Check(ctx context.Context, input, expected, actual string) (compare.Result, error) {
+	result := compare.Result{
+		Expected: expected,
+		Actual:   actual,
+	}
+
+	runResult, err := c.runner.Run(ctx, runner.RunConfig{
+		Language:    c.language,
+		SourcePath:  c.sourcePath,
+		TimeLimit:   c.timeLimit,
+		MemoryLimit: c.memoryLimit,
+		Args: []string{
+			runner.FilesDir + "/" + checkerInputFile,
+			runner.FilesDir + "/" + checkerOutputFile,
+			runner.FilesDir + "/" + checkerAnswerFile,
+		},
+		Files: map[string]string{
+			checkerInputFile:  input,
+			checkerOutputFile: actual,
+			checkerAnswerFile: expected,
+		},
+	})
+	if err != nil {
+		return result, fmt.Errorf("checker failed: %w", err)
+	}
+
+	switch runResult.Verdict {
+	case runner.VerdictAccepted, runner.VerdictRuntimeError:
+		// Non-zero exit codes carry the verdict
+	case runner.VerdictTimeLimitExceeded:
+		return result, fmt.Errorf("checker exceeded its time limit (%v)", c.timeLimit)
+	default:
+		return result, fmt.Errorf("checker failed with %s: %v", runResult.Verdict, runResult.Error)
+	}
+
+	// testlib writes its message to stderr; simple checkers often use stdout
+	result.Message = strings.TrimSpace(runResult.Stderr)
+	if result.Message == "" {
+		result.Message = strings.TrimSpace(runResult.Stdout)
+	}
+
+	switch runResult.ExitCode {
+	case checkerExitOK:
+		result.Match = true
+	case checkerExitWA:
+		result.Match = false
+	case checkerExitPE:
+		result.Match = false
+		result.PresentationError = true
+	case checkerExitPC:
+		result.Match = false
+		result.Score, err = parsePartialScore(result.Message)
+		if err != nil {
+			return result, err
+		}
+	case checkerExitFail:
+		return result, fmt.Errorf("checker reported failure: %s", result.Message)
+	default:
+		return result, fmt.Errorf("checker exited with unexpected code %d: %s", runResult.ExitCode, result.Message)
+	}
+
+	return result, nil
+}
+
+// parsePartialScore reads the score from a partial-credit checker message,
+// such as "0.5 two of four queries answered" or "points 0.5 ..."
+func parsePartialScore(message string) (float64, error) {
+	fields := strings.Fields(message)
+	if len(fields) > 0 && fields[0] == "points" {
+		fields = fields[1:]
+	}
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("checker reported partial credit without a score")
+	}
+
+	score, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil || score < 0 || score > 1 {
+		return 0, fmt.Errorf("checker reported invalid partial score %q (want a number in [0, 1])", fields[0])
+	}
+	return score, nil
+}
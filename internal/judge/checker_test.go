@@ -0,0 +1,116 @@
+package judge
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/marv972228/sandbox_judge/internal/problem"
+	"github.com/marv972228/sandbox_judge/internal/runner"
+)
+
+func TestProgramChecker_ExitCodes(t *testing.T) {
+	tests := []struct {
+		code         int
+		match        bool
+		presentation bool
+		wantErr      bool
+	}{
+		{checkerExitOK, true, false, false},
+		{checkerExitWA, false, false, false},
+		{checkerExitPE, false, true, false},
+		{checkerExitFail, false, false, true},
+		{42, false, false, true},
+	}
+
+	for _, tt := range tests {
+		fr := &fakeRunner{run: exitWith(tt.code, "message from checker\n")}
+		c, err := newProgramChecker(fr, "/problems/p/checker.py", 0, 0)
+		if err != nil {
+			t.Fatalf("newProgramChecker returned error: %v", err)
+		}
+
+		result, err := c.Check(context.Background(), "in", "ans", "out")
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("exit %d: expected error", tt.code)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("exit %d: unexpected error: %v", tt.code, err)
+		}
+		if result.Match != tt.match || result.PresentationError != tt.presentation {
+			t.Errorf("exit %d: got match=%v pe=%v, want match=%v pe=%v",
+				tt.code, result.Match, result.PresentationError, tt.match, tt.presentation)
+		}
+		if result.Message != "message from checker" {
+			t.Errorf("exit %d: expected checker message, got %q", tt.code, result.Message)
+		}
+	}
+}
+
+func TestProgramChecker_PassesFiles(t *testing.T) {
+	fr := &fakeRunner{run: exitWith(0, "")}
+	c, err := newProgramChecker(fr, "/problems/p/checker.py", 0, 0)
+	if err != nil {
+		t.Fatalf("newProgramChecker returned error: %v", err)
+	}
+
+	if _, err := c.Check(context.Background(), "the input", "the answer", "the output"); err != nil {
+		t.Fatalf("Check returned error: %v", err)
+	}
+
+	cfg := fr.configs[0]
+	if cfg.Language != "python" || cfg.SourcePath != "/problems/p/checker.py" {
+		t.Errorf("unexpected run config: %+v", cfg)
+	}
+	if cfg.Files[checkerInputFile] != "the input" ||
+		cfg.Files[checkerOutputFile] != "the output" ||
+		cfg.Files[checkerAnswerFile] != "the answer" {
+		t.Errorf("unexpected checker files: %v", cfg.Files)
+	}
+	if len(cfg.Args) != 3 || !strings.HasSuffix(cfg.Args[0], checkerInputFile) ||
+		!strings.HasSuffix(cfg.Args[1], checkerOutputFile) || !strings.HasSuffix(cfg.Args[2], checkerAnswerFile) {
+		t.Errorf("expected testlib argument order input/output/answer, got %v", cfg.Args)
+	}
+}
+
+func TestProgramChecker_TimeLimit(t *testing.T) {
+	fr := &fakeRunner{run: func(runner.RunConfig) *runner.RunResult {
+		return &runner.RunResult{Verdict: runner.VerdictTimeLimitExceeded}
+	}}
+	c, _ := newProgramChecker(fr, "checker.py", 0, 0)
+
+	if _, err := c.Check(context.Background(), "", "", ""); err == nil {
+		t.Error("Expected error when checker exceeds its time limit")
+	}
+}
+
+func TestNewProgramChecker_UnsupportedExtension(t *testing.T) {
+	if _, err := newProgramChecker(&fakeRunner{}, "checker.xyz", 0, 0); err == nil {
+		t.Error("Expected error for unsupported checker extension")
+	}
+}
+
+func TestProgramChecker_FailureIsSystemError(t *testing.T) {
+	checker := exitWith(checkerExitFail, "bad answer file\n")
+	fr := &fakeRunner{run: func(cfg runner.RunConfig) *runner.RunResult {
+		if len(cfg.Args) > 0 {
+			return checker(cfg)
+		}
+		return echoRunner(cfg)
+	}}
+	c, err := newProgramChecker(fr, "/problems/p/checker.py", 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	j := &Judge{runner: fr}
+	sub := &submission{comparator: c, problem: &problem.Problem{MemoryLimitMB: 256}, language: "python"}
+	tr := j.executeTestCase(context.Background(), sub, problem.TestCase{Name: "t", Input: "1\n", Expected: "1\n"}, time.Second)
+	if tr.Verdict != runner.VerdictSystemError || !strings.Contains(tr.Error, "bad answer file") {
+		t.Errorf("verdict = %s (%q), want SE with the checker's message", tr.Verdict, tr.Error)
+	}
+}
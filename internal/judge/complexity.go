@@ -0,0 +1,177 @@
+package judge
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/marv972228/sandbox_judge/internal/runner"
+	"github.com/marv972228/sandbox_judge/internal/stats"
+)
+
+// DefaultComplexityRepeat is the number of inputs measured per size when none is given
+const DefaultComplexityRepeat = 3
+
+// ComplexityConfig configures a complexity estimate
+type ComplexityConfig struct {
+	// Sizes overrides the problem's scaling sizes
+	Sizes []int
+
+	// Repeat is the number of generated inputs per size (0 = DefaultComplexityRepeat)
+	Repeat int
+}
+
+// ComplexityPoint holds the measurements at one input size
+type ComplexityPoint struct {
+	// Size is the input size passed to the generator
+	Size int
+
+	// Time is the median run time in milliseconds
+	Time float64
+
+	// Memory is the peak memory usage in bytes (0 if not measured)
+	Memory int64
+
+	// Verdict is AC, or the verdict that stopped the measurements (TLE, RE, ...)
+	Verdict runner.Verdict
+}
+
+// ComplexityResult holds the outcome of a complexity estimate
+type ComplexityResult struct {
+	// Language is the detected language of the solution
+	Language string
+
+	// Points are the measurements in increasing size; measuring stops at
+	// the first size the solution does not pass
+	Points []ComplexityPoint
+
+	// Fits are the candidate complexities from best to worst (empty if
+	// fewer than three sizes were measured)
+	Fits []stats.Fit
+
+	// MaxSize is the largest input size allowed by the constraints
+	MaxSize int
+
+	// Predicted is the best fit's time at MaxSize
+	Predicted time.Duration
+
+	// TimeLimit is the solution's effective per-test time limit
+	TimeLimit time.Duration
+}
+
+// ExceedsTimeLimit reports whether the solution is expected to exceed the
+// time limit at the maximum input size, either by extrapolation or because
+// it already failed at a smaller size
+func (r *ComplexityResult) ExceedsTimeLimit() bool {
+	if n := len(r.Points); n > 0 && r.Points[n-1].Verdict == runner.VerdictTimeLimitExceeded {
+		return true
+	}
+	return len(r.Fits) > 0 && r.Predicted > r.TimeLimit
+}
+
+// minComplexityPoints is the fewest sizes a curve is fitted to
+const minComplexityPoints = 3
+
+// Complexity estimates a solution's time complexity by running it on inputs
+// of increasing size from the problem's scaling generator, fitting candidate
+// growth rates to the median times and extrapolating to the maximum size.
+// Outputs are not checked; use stress or run for correctness.
+func (j *Judge) Complexity(ctx context.Context, problemID, solutionPath string, cfg ComplexityConfig) (*ComplexityResult, error) {
+	sub, err := j.prepareSolution(problemID, solutionPath)
+	if err != nil {
+		return nil, err
+	}
+
+	scaling := sub.problem.Scaling
+	if scaling == nil {
+		return nil, fmt.Errorf("problem %s has no scaling generator (add `scaling:` to problem.yaml)", problemID)
+	}
+	generatorPath := filepath.Join(j.problemLoader.Dir(problemID), scaling.Generator)
+
+	sizes := cfg.Sizes
+	if len(sizes) == 0 {
+		sizes = scaling.Sizes
+	}
+	if len(sizes) == 0 {
+		sizes = scaling.DefaultSizes()
+	}
+	repeat := cfg.Repeat
+	if repeat <= 0 {
+		repeat = DefaultComplexityRepeat
+	}
+
+	result := &ComplexityResult{
+		Language:  sub.language,
+		MaxSize:   scaling.MaxSize,
+		TimeLimit: sub.timeLimit,
+	}
+
+	for _, size := range sizes {
+		point, err := j.measureSize(ctx, sub, generatorPath, size, repeat)
+		if err != nil {
+			return nil, err
+		}
+		result.Points = append(result.Points, point)
+		if point.Verdict != runner.VerdictAccepted {
+			break
+		}
+	}
+
+	var xs, ys []float64
+	for _, p := range result.Points {
+		if p.Verdict == runner.VerdictAccepted {
+			xs = append(xs, float64(p.Size))
+			ys = append(ys, p.Time)
+		}
+	}
+	if len(xs) >= minComplexityPoints {
+		result.Fits = stats.FitComplexity(xs, ys)
+		ms := result.Fits[0].Predict(float64(scaling.MaxSize))
+		result.Predicted = time.Duration(ms * float64(time.Millisecond))
+	}
+
+	return result, nil
+}
+
+// measureSize runs the solution on repeat generated inputs of one size
+func (j *Judge) measureSize(ctx context.Context, sub *submission, generatorPath string, size, repeat int) (ComplexityPoint, error) {
+	point := ComplexityPoint{Size: size, Verdict: runner.VerdictAccepted}
+
+	var times []float64
+	for seed := 1; seed <= repeat; seed++ {
+		input, err := j.runHelper(ctx, generatorPath, "", strconv.Itoa(seed), strconv.Itoa(size))
+		if err != nil {
+			return point, fmt.Errorf("generator failed for size %d: %w", size, err)
+		}
+
+		res, err := j.runner.Run(ctx, runner.RunConfig{
+			Language:     sub.language,
+			SourcePath:   sub.sourcePath,
+			Stdin:        input,
+			TimeLimit:    sub.timeLimit,
+			MemoryLimit:  int64(sub.problem.MemoryLimitMB) * 1024 * 1024,
+			SampleMemory: true,
+		})
+		if err != nil {
+			return point, err
+		}
+		if res.Verdict == runner.VerdictSystemError {
+			return point, fmt.Errorf("size %d: %v", size, res.Error)
+		}
+
+		if res.MemoryUsed > point.Memory {
+			point.Memory = res.MemoryUsed
+		}
+		if res.Verdict != runner.VerdictAccepted {
+			point.Verdict = res.Verdict
+			point.Time = milliseconds(res.Duration)
+			return point, nil
+		}
+		times = append(times, milliseconds(res.Duration))
+	}
+
+	point.Time = stats.Summarize(times).Median
+	return point, nil
+}
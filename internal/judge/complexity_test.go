@@ -0,0 +1,82 @@
+package judge
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/marv972228/sandbox_judge/internal/runner"
+)
+
+// quadratic takes 30ms + n^2/1000 ms on an input holding n
+func quadratic(cfg runner.RunConfig) *runner.RunResult {
+	n, _ := strconv.Atoi(strings.TrimSpace(cfg.Stdin))
+	d := 30*time.Millisecond + time.Duration(n*n)*time.Microsecond
+	if d > cfg.TimeLimit {
+		return timesOut(cfg)
+	}
+	return &runner.RunResult{Verdict: runner.VerdictAccepted, Duration: d}
+}
+
+// quadraticPrograms are a generator that prints the size and a quadratic solution
+var quadraticPrograms = programs{"gen.py": printsArg(1), "solution.py": quadratic}
+
+func TestComplexity_QuadraticExceedsLimit(t *testing.T) {
+	files := echoTests([]string{"sample/1"})
+	files["../gen.py"] = ""
+	yaml := "id: p\ntime_limit_ms: 1000\nscaling:\n  generator: gen.py\n  max_size: 1000\n  sizes: [50, 100, 200, 400]\n"
+	loader := writeProblem(t, "p", yaml, files)
+	fr := &fakeRunner{run: quadraticPrograms.run}
+	j := &Judge{problemLoader: loader, runner: fr, timeLimit: time.Second}
+
+	result, err := j.Complexity(context.Background(), "p", "solution.py", ComplexityConfig{Repeat: 2})
+	if err != nil {
+		t.Fatalf("Complexity returned error: %v", err)
+	}
+
+	if len(result.Points) != 4 || result.Points[3].Time != 190 {
+		t.Errorf("Points = %+v, want 4 points ending at 190ms", result.Points)
+	}
+	if len(result.Fits) == 0 || result.Fits[0].Complexity.Name != "O(n^2)" {
+		t.Fatalf("best fit = %+v, want O(n^2)", result.Fits)
+	}
+	if result.Predicted.Round(time.Millisecond) != 1030*time.Millisecond {
+		t.Errorf("Predicted = %v, want 1.03s", result.Predicted)
+	}
+	if !result.ExceedsTimeLimit() {
+		t.Error("Expected the extrapolated time to exceed the limit")
+	}
+	if len(fr.configs) != 16 {
+		t.Errorf("runner called %d times, want 16 (generator and solution, 2 per size)", len(fr.configs))
+	}
+}
+
+func TestComplexity_StopsAtTLE(t *testing.T) {
+	files := echoTests([]string{"sample/1"})
+	files["../gen.py"] = ""
+	yaml := "id: p\nscaling:\n  generator: gen.py\n  max_size: 10000\n  sizes: [100, 400, 2000, 4000]\n"
+	loader := writeProblem(t, "p", yaml, files)
+	j := &Judge{problemLoader: loader, runner: &fakeRunner{run: quadraticPrograms.run}, timeLimit: time.Second}
+
+	result, err := j.Complexity(context.Background(), "p", "solution.py", ComplexityConfig{Repeat: 1})
+	if err != nil {
+		t.Fatalf("Complexity returned error: %v", err)
+	}
+	if len(result.Points) != 3 || result.Points[2].Verdict != runner.VerdictTimeLimitExceeded {
+		t.Errorf("Points = %+v, want measurements to stop at the TLE for 2000", result.Points)
+	}
+	if len(result.Fits) != 0 || !result.ExceedsTimeLimit() {
+		t.Errorf("Expected no fit and an exceeded limit, got %+v", result)
+	}
+}
+
+func TestComplexity_NoScaling(t *testing.T) {
+	loader := writeProblem(t, "p", "id: p\n", echoTests([]string{"sample/1"}))
+	j := &Judge{problemLoader: loader, runner: &fakeRunner{run: echoRunner}}
+
+	if _, err := j.Complexity(context.Background(), "p", "solution.py", ComplexityConfig{}); err == nil {
+		t.Error("Expected error for a problem without scaling")
+	}
+}
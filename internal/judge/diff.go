@@ -0,0 +1,211 @@
+package judge
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/marv972228/sandbox_judge/internal/problem"
+	"github.com/marv972228/sandbox_judge/internal/runner"
+)
+
+// DefaultDiffInputs is the number of generated inputs tried when a
+// generator is given without a count
+const DefaultDiffInputs = 100
+
+// DiffConfig configures a differential run
+type DiffConfig struct {
+	// GeneratorPath optionally adds generated inputs; it prints a random
+	// input for the seed in its first argument
+	GeneratorPath string
+
+	// Inputs is the number of generated inputs (0 = DefaultDiffInputs)
+	Inputs int
+
+	// Seed is the seed of the first generated input
+	Seed int64
+}
+
+// DiffTest compares two solutions on one test
+type DiffTest struct {
+	// TestCase is the test both solutions ran on. Generated inputs are named
+	// "gen/seed-N" and have no expected output.
+	TestCase problem.TestCase
+
+	// A and B are the results of the two solutions
+	A, B TestResult
+
+	// OutputsDiffer is true if both solutions ran normally and the problem's
+	// comparator rejects B's output given A's
+	OutputsDiffer bool
+
+	// Message explains how the outputs differ
+	Message string
+}
+
+// Differs reports whether the solutions disagree on the test
+func (d DiffTest) Differs() bool {
+	return d.A.Verdict != d.B.Verdict || d.OutputsDiffer
+}
+
+// DiffResult holds the outcome of a differential run
+type DiffResult struct {
+	// ProblemID is the problem the solutions were run on
+	ProblemID string
+
+	// LanguageA and LanguageB are the detected languages of the solutions
+	LanguageA, LanguageB string
+
+	// Tests holds one comparison per test case, then per generated input
+	Tests []DiffTest
+}
+
+// Differing returns the tests on which the solutions disagree
+func (r *DiffResult) Differing() []DiffTest {
+	var out []DiffTest
+	for _, d := range r.Tests {
+		if d.Differs() {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// Diff runs two solutions on every test case, and optionally on generated
+// inputs, and compares their verdicts, times, memory and outputs. The runs
+// are interleaved and sequential so both solutions see the same machine load.
+func (j *Judge) Diff(ctx context.Context, problemID, pathA, pathB string, cfg DiffConfig) (*DiffResult, error) {
+	subA, testCases, err := j.prepare(ctx, problemID, pathA, Selection{})
+	if err != nil {
+		return nil, err
+	}
+	subB, err := j.prepareSolution(problemID, pathB)
+	if err != nil {
+		return nil, err
+	}
+	subA.sampleMemory, subB.sampleMemory = true, true
+
+	result := &DiffResult{ProblemID: problemID, LanguageA: subA.language, LanguageB: subB.language}
+
+	for _, tc := range testCases {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		d, err := j.diffTest(ctx, subA, subB, tc, true)
+		if err != nil {
+			return nil, err
+		}
+		result.Tests = append(result.Tests, d)
+	}
+
+	if cfg.GeneratorPath == "" {
+		return result, nil
+	}
+	inputs := cfg.Inputs
+	if inputs <= 0 {
+		inputs = DefaultDiffInputs
+	}
+	for i := 0; i < inputs; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		seed := cfg.Seed + int64(i)
+		input, err := j.runHelper(ctx, cfg.GeneratorPath, "", strconv.FormatInt(seed, 10))
+		if err != nil {
+			return nil, fmt.Errorf("generator failed for seed %d: %w", seed, err)
+		}
+
+		tc := problem.TestCase{Name: fmt.Sprintf("gen/seed-%d", seed), Input: input}
+		d, err := j.diffTest(ctx, subA, subB, tc, false)
+		if err != nil {
+			return nil, err
+		}
+		result.Tests = append(result.Tests, d)
+	}
+
+	return result, nil
+}
+
+// diffTest runs both solutions on a test and compares their outputs.
+// Without an expected output, verdicts only say whether a solution ran
+// normally.
+func (j *Judge) diffTest(ctx context.Context, subA, subB *submission, tc problem.TestCase, judged bool) (DiffTest, error) {
+	d := DiffTest{TestCase: tc}
+	for _, side := range []struct {
+		sub *submission
+		out *TestResult
+	}{{subA, &d.A}, {subB, &d.B}} {
+		timeLimit := j.testTimeLimit(side.sub, tc)
+		if judged {
+			*side.out = j.executeTestCase(ctx, side.sub, tc, timeLimit)
+		} else {
+			*side.out = j.executeUnjudged(ctx, side.sub, tc, timeLimit)
+		}
+		side.out.TimeLimit = timeLimit
+		// A run cut short by cancellation says nothing about either solution
+		if err := ctx.Err(); err != nil {
+			return d, err
+		}
+		if side.out.Verdict == runner.VerdictSystemError {
+			return d, fmt.Errorf("%s: %s", tc.Name, side.out.Error)
+		}
+	}
+
+	if ranNormally(d.A.Verdict) && ranNormally(d.B.Verdict) {
+		// A's output plays the expected answer, as a reference does in stress
+		cross := problem.TestCase{Name: tc.Name, Input: tc.Input, Expected: d.A.Actual}
+		comparison, err := j.compareOutput(ctx, subB.comparator, cross, d.B.Actual)
+		if err != nil {
+			return d, fmt.Errorf("%s: %w", tc.Name, err)
+		}
+		d.OutputsDiffer = !comparison.Match
+		d.Message = comparison.Message
+	}
+
+	if tc.Meta.Hidden {
+		redactHidden(&d.A)
+		redactHidden(&d.B)
+		d.TestCase = redactTestCase(d.TestCase)
+	}
+	return d, nil
+}
+
+// executeUnjudged runs a solution on an input without an expected output;
+// the verdict is AC if it exited normally within the limits
+func (j *Judge) executeUnjudged(ctx context.Context, sub *submission, tc problem.TestCase, timeLimit time.Duration) TestResult {
+	res, err := j.runner.Run(ctx, runner.RunConfig{
+		Language:     sub.language,
+		SourcePath:   sub.sourcePath,
+		Stdin:        tc.Input,
+		TimeLimit:    timeLimit,
+		MemoryLimit:  int64(sub.problem.MemoryLimitMB) * 1024 * 1024,
+		SampleMemory: sub.sampleMemory,
+	})
+	if err != nil {
+		return TestResult{TestCase: tc, Verdict: runner.VerdictSystemError, Error: err.Error()}
+	}
+	tr := TestResult{
+		TestCase: tc,
+		Verdict:  res.Verdict,
+		Duration: res.Duration,
+		Memory:   res.MemoryUsed,
+		Actual:   res.Stdout,
+		Error:    res.Stderr,
+	}
+	if res.Verdict == runner.VerdictSystemError && res.Error != nil {
+		tr.Error = res.Error.Error()
+	}
+	return tr
+}
+
+// ranNormally reports whether a verdict means the program exited normally
+// within its limits, so that its output can be compared
+func ranNormally(v runner.Verdict) bool {
+	switch v {
+	case runner.VerdictAccepted, runner.VerdictWrongAnswer, runner.VerdictPresentationError:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package judge
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/marv972228/sandbox_judge/internal/runner"
+)
+
+// diffPrograms are a generator that prints its seed, a solution a.py that
+// echoes, and b.py, which echoes except that it prints "x" (taking 2ms) on
+// hidden/2 and for seed 2, and times out on hidden/3
+var diffPrograms = programs{
+	"gen.py": printsArg(0),
+	"b.py": byInput(map[string]play{
+		"hidden/2\n": printsX,
+		"2\n":        printsX,
+		"hidden/3\n": timesOut,
+	}),
+}
+
+func printsX(runner.RunConfig) *runner.RunResult {
+	return &runner.RunResult{Verdict: runner.VerdictAccepted, Stdout: "x\n", Duration: 2 * time.Millisecond}
+}
+
+func TestDiff(t *testing.T) {
+	loader := writeProblem(t, "p", "id: p\n", echoTests([]string{"sample/1", "hidden/1", "hidden/2", "hidden/3"}))
+	j := &Judge{problemLoader: loader, runner: &fakeRunner{run: diffPrograms.run}}
+
+	result, err := j.Diff(context.Background(), "p", "a.py", "b.py", DiffConfig{GeneratorPath: "gen.py", Inputs: 3, Seed: 1})
+	if err != nil {
+		t.Fatalf("Diff returned error: %v", err)
+	}
+
+	if len(result.Tests) != 7 {
+		t.Fatalf("Compared %d tests, want 4 tests and 3 generated inputs", len(result.Tests))
+	}
+
+	var differing []string
+	for _, d := range result.Differing() {
+		differing = append(differing, d.TestCase.Name)
+	}
+	if got := strings.Join(differing, " "); got != "hidden/2 hidden/3 gen/seed-2" {
+		t.Errorf("Differing tests = %s, want hidden/2 hidden/3 gen/seed-2", got)
+	}
+
+	wrong := result.Tests[2]
+	if !wrong.OutputsDiffer || wrong.B.Verdict != runner.VerdictWrongAnswer || wrong.B.Duration != 2*time.Millisecond {
+		t.Errorf("Unexpected comparison on hidden/2: %+v", wrong)
+	}
+	// A timeout has no output to compare; the verdicts differ instead
+	slow := result.Tests[3]
+	if slow.OutputsDiffer || slow.B.Verdict != runner.VerdictTimeLimitExceeded {
+		t.Errorf("Unexpected comparison on hidden/3: %+v", slow)
+	}
+	// Generated inputs have no expected output, so both verdicts are AC
+	gen := result.Tests[5]
+	if gen.A.Verdict != runner.VerdictAccepted || gen.B.Verdict != runner.VerdictAccepted || !gen.OutputsDiffer {
+		t.Errorf("Unexpected comparison on %s: %+v", gen.TestCase.Name, gen)
+	}
+}
+
+func TestDiff_HiddenTestsRedacted(t *testing.T) {
+	yaml := "id: p\ntests:\n  hidden/2: {hidden: true}\n"
+	loader := writeProblem(t, "p", yaml, echoTests([]string{"hidden/1", "hidden/2"}))
+	j := &Judge{problemLoader: loader, runner: &fakeRunner{run: diffPrograms.run}}
+
+	result, err := j.Diff(context.Background(), "p", "a.py", "b.py", DiffConfig{})
+	if err != nil {
+		t.Fatalf("Diff returned error: %v", err)
+	}
+
+	hidden := result.Tests[1]
+	if !hidden.OutputsDiffer {
+		t.Error("Outputs of a hidden test should still be compared")
+	}
+	if hidden.TestCase.Input != "" || hidden.A.Actual != "" || hidden.B.Actual != "" {
+		t.Errorf("Hidden test leaked input or output: %+v", hidden)
+	}
+}
+
+func TestDiff_Cancelled(t *testing.T) {
+	loader := writeProblem(t, "p", "id: p\n", echoTests([]string{"sample/1", "sample/2"}))
+
+	// B is killed by the cancellation while running the last test
+	ctx, cancel := context.WithCancel(context.Background())
+	killed := func(cfg runner.RunConfig) *runner.RunResult {
+		cancel()
+		return timesOut(cfg)
+	}
+	fake := programs{"b.py": byInput(map[string]play{"sample/2\n": killed})}
+	j := &Judge{problemLoader: loader, runner: &fakeRunner{run: fake.run}}
+
+	result, err := j.Diff(ctx, "p", "a.py", "b.py", DiffConfig{})
+	if err != context.Canceled || result != nil {
+		t.Errorf("Diff = %+v, %v; want context.Canceled and no result", result, err)
+	}
+}
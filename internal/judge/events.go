@@ -0,0 +1,118 @@
+package judge
+
+import (
+	"time"
+
+	"github.com/marv972228/sandbox_judge/internal/problem"
+)
+
+// EventType identifies a progress event emitted while judging
+type EventType string
+
+const (
+	EventCompileStart EventType = "compile-start" // Solution is being prepared for running
+	EventCompileEnd   EventType = "compile-end"   // Solution is ready, or failed to prepare
+	EventTestStart    EventType = "test-start"    // A test case started running
+	EventTestEnd      EventType = "test-end"      // A test case finished (or was skipped)
+	EventRunDone      EventType = "run-done"      // The run finished or was interrupted; Result is final
+)
+
+// Event describes progress of a judging run
+type Event struct {
+	// Type says what happened
+	Type EventType
+
+	// ProblemID is the problem being judged
+	ProblemID string
+
+	// Language is the solution's language (compile and test events)
+	Language string
+
+	// TimeLimit is the effective per-test time limit for Language
+	TimeLimit time.Duration
+
+	// Total is the number of test cases in the run
+	Total int
+
+	// Index is the test case's position in the run, from 0 (test events)
+	Index int
+
+	// TestCase is the test case that started or ended (test events)
+	TestCase problem.TestCase
+
+	// TestResult is the outcome of the test case (EventTestEnd)
+	TestResult *TestResult
+
+	// Result is the final result of the run, partial if it was interrupted
+	// (EventRunDone)
+	Result *Result
+
+	// Duration is how long the compile step took (EventCompileEnd)
+	Duration time.Duration
+
+	// Error is set when the solution could not be prepared (EventCompileEnd)
+	Error string
+}
+
+// Observer receives progress events. The judge serializes calls, so an
+// observer does not need its own locking, but it should return quickly
+// because tests wait while it runs.
+type Observer interface {
+	OnEvent(e Event)
+}
+
+// ObserverFunc adapts a function to the Observer interface
+type ObserverFunc func(e Event)
+
+// OnEvent calls f(e)
+func (f ObserverFunc) OnEvent(e Event) {
+	f(e)
+}
+
+// emit delivers an event to the observer, if any
+func (j *Judge) emit(e Event) {
+	if j.observer == nil {
+		return
+	}
+	j.observerMu.Lock()
+	defer j.observerMu.Unlock()
+	j.observer.OnEvent(e)
+}
+
+// emitTest delivers a test event for a submission. The result is copied,
+// since fail-fast may still replace it with a skip, and hidden tests are
+// sent without their data.
+func (j *Judge) emitTest(sub *submission, typ EventType, tc problem.TestCase, result *TestResult) {
+	if result != nil {
+		r := *result
+		if tc.Meta.Hidden {
+			redactHidden(&r)
+		}
+		result = &r
+	}
+	if tc.Meta.Hidden {
+		tc = redactTestCase(tc)
+	}
+	j.emit(Event{
+		Type:       typ,
+		ProblemID:  sub.problem.ID,
+		Language:   sub.language,
+		TimeLimit:  sub.timeLimit,
+		Total:      len(sub.testIndex),
+		Index:      sub.testIndex[tc.Name],
+		TestCase:   tc,
+		TestResult: result,
+	})
+}
+
+// emitDone delivers the final result of a run
+func (j *Judge) emitDone(sub *submission, result *Result) {
+	j.emit(Event{
+		Type:      EventRunDone,
+		ProblemID: sub.problem.ID,
+		Language:  sub.language,
+		TimeLimit: sub.timeLimit,
+		Total:     result.Total,
+		Result:    result,
+	})
+}
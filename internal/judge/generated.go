@@ -0,0 +1,34 @@
+package judge
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/marv972228/sandbox_judge/internal/problem"
+)
+
+// buildTest builds one generated test in the sandbox: the generator prints
+// the input for a seed, and the problem's reference solution answers it
+func (j *Judge) buildTest(ctx context.Context, p *problem.Problem, g problem.GeneratedTests, seed int64) (string, string, error) {
+	dir := j.problemLoader.Dir(p.ID)
+
+	args := append([]string{strconv.FormatInt(seed, 10)}, g.Args...)
+	input, err := j.runHelper(ctx, filepath.Join(dir, g.Generator), "", args...)
+	if err != nil {
+		return "", "", fmt.Errorf("generator failed: %w", err)
+	}
+
+	expected, err := j.runReference(ctx, p, filepath.Join(dir, p.Reference), input)
+	if err != nil {
+		return "", "", fmt.Errorf("reference failed: %w", err)
+	}
+	return input, expected, nil
+}
+
+// TestCases loads a problem's test cases, building generated tests that are
+// not cached yet
+func (j *Judge) TestCases(ctx context.Context, problemID string) ([]problem.TestCase, error) {
+	return j.problemLoader.LoadTestCases(ctx, problemID)
+}
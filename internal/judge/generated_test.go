@@ -0,0 +1,52 @@
+package judge
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/marv972228/sandbox_judge/internal/runner"
+)
+
+// generatedPrograms are a generator that prints its arguments and a
+// reference that answers with the input's first token
+var generatedPrograms = programs{
+	"gen.py": func(cfg runner.RunConfig) *runner.RunResult {
+		return prints(strings.Join(cfg.Args, " ") + "\n")(cfg)
+	},
+	"ref.py": func(cfg runner.RunConfig) *runner.RunResult {
+		return prints(strings.Fields(cfg.Stdin)[0] + "\n")(cfg)
+	},
+}
+
+func TestRun_GeneratedTests(t *testing.T) {
+	files := echoTests([]string{"sample/1"})
+	files["../gen.py"] = ""
+	files["../ref.py"] = ""
+	yaml := `id: p
+reference: ref.py
+generated:
+  - {generator: gen.py, args: [big], seeds: [3, 4]}
+`
+	loader := writeProblem(t, "p", yaml, files)
+	fake := &fakeRunner{run: generatedPrograms.run}
+	j := &Judge{problemLoader: loader, runner: fake, policy: PolicyAll}
+	loader.SetTestBuilder(j.buildTest)
+
+	result, err := j.Run(context.Background(), "p", "solution.py", Selection{})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	if result.Total != 3 {
+		t.Fatalf("Total = %d, want 3", result.Total)
+	}
+	tr := result.TestResults[1]
+	if tr.TestCase.Name != "generated/1" || tr.TestCase.Input != "3 big\n" || tr.TestCase.Expected != "3\n" {
+		t.Errorf("Unexpected generated test: %+v", tr.TestCase)
+	}
+	// The solution echoes "3 big" where the reference says "3"
+	if tr.Verdict != runner.VerdictWrongAnswer {
+		t.Errorf("generated/1 verdict = %s, want WA", tr.Verdict)
+	}
+}
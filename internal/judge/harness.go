@@ -0,0 +1,66 @@
+package judge
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/marv972228/sandbox_judge/internal/harness"
+	"github.com/marv972228/sandbox_judge/internal/problem"
+	"github.com/marv972228/sandbox_judge/internal/runner"
+)
+
+// wrap returns the path of a program that runs the solution at path through
+// the language's harness, or path itself if the problem reads stdin. Wrapped
+// programs live until the Judge is closed.
+func (j *Judge) wrap(prob *problem.Problem, language, path string) (string, error) {
+	if prob.Signature == nil {
+		return path, nil
+	}
+
+	h, err := harness.For(language)
+	if err != nil {
+		return "", err
+	}
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read solution: %w", err)
+	}
+	program := h.Wrap(prob.Signature, string(source))
+
+	j.wrapMu.Lock()
+	defer j.wrapMu.Unlock()
+
+	if j.wrapDir == "" {
+		dir, err := os.MkdirTemp("", "sandbox-judge-harness-")
+		if err != nil {
+			return "", fmt.Errorf("failed to create harness directory: %w", err)
+		}
+		j.wrapDir = dir
+	}
+
+	// Name by content so that wrapping the same solution again reuses the file
+	sum := sha256.Sum256([]byte(program))
+	wrapped := filepath.Join(j.wrapDir, hex.EncodeToString(sum[:8])+filepath.Ext(path))
+	if err := runner.WriteSandboxFile(wrapped, program); err != nil {
+		return "", fmt.Errorf("failed to write wrapped solution: %w", err)
+	}
+	return wrapped, nil
+}
+
+// runReference runs a trusted solution of the problem on an input and
+// returns its output, wrapping it in the harness like submitted solutions
+func (j *Judge) runReference(ctx context.Context, prob *problem.Problem, path, input string) (string, error) {
+	language := extensionToLanguage(filepath.Ext(path))
+	if language == "" {
+		return j.runHelper(ctx, path, input) // Reports the unsupported extension
+	}
+	wrapped, err := j.wrap(prob, language, path)
+	if err != nil {
+		return "", err
+	}
+	return j.runHelper(ctx, wrapped, input)
+}
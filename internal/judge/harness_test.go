@@ -0,0 +1,95 @@
+package judge
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/marv972228/sandbox_judge/internal/runner"
+)
+
+const signatureProblem = `id: p
+signature:
+  function: add
+  params:
+    - name: a
+      type: int
+    - name: b
+      type: int[]
+  returns: int
+`
+
+// hostPython runs programs with python3 on the host instead of a container
+func hostPython(t *testing.T) func(cfg runner.RunConfig) *runner.RunResult {
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skip("python3 not installed")
+	}
+	return func(cfg runner.RunConfig) *runner.RunResult {
+		cmd := exec.Command("python3", cfg.SourcePath)
+		cmd.Stdin = strings.NewReader(cfg.Stdin)
+		var stderr strings.Builder
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+		verdict := runner.VerdictAccepted
+		if err != nil {
+			verdict = runner.VerdictRuntimeError
+		}
+		return &runner.RunResult{Verdict: verdict, Stdout: string(out), Stderr: stderr.String()}
+	}
+}
+
+func TestRun_Signature(t *testing.T) {
+	loader := writeProblem(t, "p", signatureProblem, map[string]string{
+		"sample/1.in":  "1\n[2,3]\n",
+		"sample/1.out": "6\n",
+		"sample/2.in":  "0\n[]\n",
+		"sample/2.out": "0\n",
+	})
+	solution := filepath.Join(t.TempDir(), "solution.py")
+	source := "class Solution:\n    def add(self, a: int, b: List[int]) -> int:\n        return a + sum(b)\n"
+	if err := os.WriteFile(solution, []byte(source), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	fr := &fakeRunner{run: hostPython(t)}
+	j := &Judge{problemLoader: loader, runner: fr, jobs: 1}
+
+	result, err := j.Run(context.Background(), "p", solution, Selection{})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	for _, tr := range result.TestResults {
+		if tr.Verdict != runner.VerdictAccepted {
+			t.Errorf("%s: verdict %s, output %q, error %q", tr.TestCase.Name, tr.Verdict, tr.Actual, tr.Error)
+		}
+	}
+
+	wrapped := fr.configs[0].SourcePath
+	if wrapped == solution {
+		t.Fatal("solution was run without the harness")
+	}
+	if err := j.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(wrapped); !os.IsNotExist(err) {
+		t.Errorf("wrapped solution %s still exists after Close", wrapped)
+	}
+}
+
+func TestRun_SignatureUnsupportedLanguage(t *testing.T) {
+	loader := writeProblem(t, "p", signatureProblem, echoTests([]string{"sample/1"}))
+	j := &Judge{problemLoader: loader, runner: &fakeRunner{run: echoRunner}, jobs: 1}
+	defer j.Close()
+
+	solution := filepath.Join(t.TempDir(), "solution.js")
+	if err := os.WriteFile(solution, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := j.Run(context.Background(), "p", solution, Selection{})
+	if err == nil || !strings.Contains(err.Error(), "not supported for javascript") {
+		t.Errorf("Run error = %v, want unsupported language", err)
+	}
+}
@@ -4,7 +4,11 @@ package judge
 import (
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
+	"runtime"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/marv972228/sandbox_judge/internal/compare"
@@ -29,8 +33,20 @@ type TestResult struct {
 	// Actual output from the submission
 	Actual string
 
+	// Message explains why the output was rejected (from the comparator)
+	Message string
+
 	// Error message if any
 	Error string
+
+	// Score is the credit earned in [0, 1]: 1 for AC, partial credit from a checker, else 0
+	Score float64
+
+	// TimeLimit is the time limit the test case ran under
+	TimeLimit time.Duration
+
+	// Memory is the peak memory usage in bytes (0 if not measured)
+	Memory int64
 }
 
 // Result holds the overall outcome of judging a submission
@@ -50,21 +66,73 @@ type Result struct {
 	// Passed is the count of AC test cases
 	Passed int
 
+	// Skipped is the count of test cases not run due to the evaluation policy
+	// or an interruption
+	Skipped int
+
 	// Total is the total number of test cases
 	Total int
+
+	// Language is the detected language of the solution
+	Language string
+
+	// TimeLimit is the effective per-test time limit for Language
+	TimeLimit time.Duration
+
+	// Subtasks holds per-subtask scores (empty if the problem has no subtasks)
+	Subtasks []SubtaskResult
+
+	// Score is the total points earned across subtasks
+	Score float64
+
+	// MaxScore is the total points available across the scored subtasks
+	MaxScore float64
 }
 
 // Judge orchestrates the evaluation of submissions
 type Judge struct {
 	problemLoader *problem.Loader
 	runner        runner.Runner
-	comparator    compare.Comparator
+	timeLimit     time.Duration
+	jobs          int
+	policy        Policy
+	observer      Observer
+	observerMu    sync.Mutex
+
+	// wrapDir holds solutions wrapped in a harness, created on first use
+	wrapDir string
+	wrapMu  sync.Mutex
 }
 
 // Config holds configuration for the Judge
 type Config struct {
 	ProblemsDir string
 	DockerDir   string
+
+	// TimeLimit overrides every problem and language time limit (0 = no override)
+	TimeLimit time.Duration
+
+	// Jobs is the number of test cases run concurrently (0 = available cores minus one)
+	Jobs int
+
+	// Policy decides which test cases run after a failure (default: PolicyAll)
+	Policy Policy
+
+	// Observer receives progress events while judging (optional)
+	Observer Observer
+}
+
+// submission bundles a solution with the problem it is judged against
+type submission struct {
+	problem    *problem.Problem
+	comparator outputChecker
+	sourcePath string
+	language   string
+	timeLimit  time.Duration
+	testIndex  map[string]int // Position of each test case by name
+	unselected []string       // Test cases left out by the selection
+
+	sampleMemory bool // Measure peak memory, for commands that report it
 }
 
 // New creates a new Judge instance
@@ -78,34 +146,80 @@ func New(cfg Config) (*Judge, error) {
 		return nil, fmt.Errorf("failed to create runner: %w", err)
 	}
 
-	// Use default comparator
-	comp := compare.NewDefaultComparator()
-
-	return &Judge{
+	j := &Judge{
 		problemLoader: loader,
 		runner:        r,
-		comparator:    comp,
-	}, nil
+		timeLimit:     cfg.TimeLimit,
+		jobs:          cfg.Jobs,
+		policy:        cfg.Policy,
+		observer:      cfg.Observer,
+	}
+	loader.SetTestBuilder(j.buildTest)
+	return j, nil
 }
 
-// Run evaluates a submission against a problem
-func (j *Judge) Run(ctx context.Context, problemID, solutionPath string) (*Result, error) {
-	// Load the problem
-	prob, err := j.problemLoader.Load(problemID)
+// prepare loads a problem and the selected test cases and resolves the solution
+func (j *Judge) prepare(ctx context.Context, problemID, solutionPath string, sel Selection) (*submission, []problem.TestCase, error) {
+	prob, err := j.loadProblem(problemID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load problem %s: %w", problemID, err)
+		return nil, nil, err
 	}
 
 	// Load test cases
-	testCases, err := j.problemLoader.LoadTestCases(problemID)
+	testCases, err := j.problemLoader.LoadTestCases(ctx, problemID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load test cases: %w", err)
+		return nil, nil, fmt.Errorf("failed to load test cases: %w", err)
 	}
 
 	if len(testCases) == 0 {
-		return nil, fmt.Errorf("no test cases found for problem %s", problemID)
+		return nil, nil, fmt.Errorf("no test cases found for problem %s", problemID)
+	}
+
+	all := testCases
+	testCases, err = sel.apply(testCases)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sub, err := j.newSubmission(prob, solutionPath, len(testCases))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sub.testIndex = make(map[string]int, len(testCases))
+	for i, tc := range testCases {
+		sub.testIndex[tc.Name] = i
+	}
+	for _, tc := range all {
+		if _, ok := sub.testIndex[tc.Name]; !ok {
+			sub.unselected = append(sub.unselected, tc.Name)
+		}
+	}
+	return sub, testCases, nil
+}
+
+// prepareSolution loads a problem and resolves the solution without loading
+// (or building) the test cases, for commands that make their own inputs
+func (j *Judge) prepareSolution(problemID, solutionPath string) (*submission, error) {
+	prob, err := j.loadProblem(problemID)
+	if err != nil {
+		return nil, err
+	}
+	return j.newSubmission(prob, solutionPath, 0)
+}
+
+// loadProblem loads a problem definition
+func (j *Judge) loadProblem(problemID string) (*problem.Problem, error) {
+	prob, err := j.problemLoader.Load(problemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load problem %s: %w", problemID, err)
 	}
+	return prob, nil
+}
 
+// newSubmission resolves a solution's language, time limit, comparator and
+// harness for a problem. numTests is reported in the compile events.
+func (j *Judge) newSubmission(prob *problem.Problem, solutionPath string, numTests int) (*submission, error) {
 	// Get absolute path for solution
 	absSolutionPath, err := filepath.Abs(solutionPath)
 	if err != nil {
@@ -119,40 +233,268 @@ func (j *Judge) Run(ctx context.Context, problemID, solutionPath string) (*Resul
 		return nil, fmt.Errorf("unsupported file extension: %s", ext)
 	}
 
+	timeLimit := j.effectiveTimeLimit(prob, language, prob.TimeLimit())
+	compileEvent := Event{
+		ProblemID: prob.ID,
+		Language:  language,
+		TimeLimit: timeLimit,
+		Total:     numTests,
+	}
+
+	// The runner compiles inside every run, so this step only sets up the
+	// comparator and the harness; the events still bracket it so observers
+	// see one phase
+	compileStart := time.Now()
+	compileEvent.Type = EventCompileStart
+	j.emit(compileEvent)
+
+	// Use the comparator configured by the problem
+	comp, err := j.newComparator(prob)
+	if err == nil {
+		absSolutionPath, err = j.wrap(prob, language, absSolutionPath)
+	}
+
+	compileEvent.Type = EventCompileEnd
+	compileEvent.Duration = time.Since(compileStart)
+	if err != nil {
+		compileEvent.Error = err.Error()
+	}
+	j.emit(compileEvent)
+	if err != nil {
+		return nil, err
+	}
+
+	return &submission{
+		problem:    prob,
+		comparator: comp,
+		sourcePath: absSolutionPath,
+		language:   language,
+		timeLimit:  timeLimit,
+	}, nil
+}
+
+// newComparator creates the output checker selected by a problem's comparison settings
+func (j *Judge) newComparator(prob *problem.Problem) (outputChecker, error) {
+	if prob.Comparison == problem.CompareCustom {
+		checkerPath := filepath.Join(j.problemLoader.Dir(prob.ID), prob.Comparator)
+		return newProgramChecker(j.runner, checkerPath,
+			time.Duration(prob.CheckerTimeLimitMS)*time.Millisecond,
+			int64(prob.CheckerMemoryLimitMB)*1024*1024)
+	}
+
+	comp, err := compare.New(string(prob.Comparison), compare.Options{
+		FloatTolerance:  prob.FloatTolerance,
+		UnorderedTokens: prob.UnorderedTokens,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create comparator for %s: %w", prob.ID, err)
+	}
+	return comparatorChecker{comparator: comp}, nil
+}
+
+// Run evaluates a submission against the selected test cases of a problem.
+// If ctx is cancelled, it returns the results of the tests that finished,
+// with the rest skipped, along with ctx's error.
+func (j *Judge) Run(ctx context.Context, problemID, solutionPath string, sel Selection) (*Result, error) {
+	return j.run(ctx, problemID, solutionPath, sel, j.policy)
+}
+
+// run evaluates a submission with the given evaluation policy
+func (j *Judge) run(ctx context.Context, problemID, solutionPath string, sel Selection, policy Policy) (*Result, error) {
+	sub, testCases, err := j.prepare(ctx, problemID, solutionPath, sel)
+	if err != nil {
+		return nil, err
+	}
+
 	// Prepare result
 	result := &Result{
 		ProblemID:    problemID,
 		TestResults:  make([]TestResult, 0, len(testCases)),
 		FinalVerdict: runner.VerdictAccepted,
 		Total:        len(testCases),
+		Language:     sub.language,
+		TimeLimit:    sub.timeLimit,
 	}
 
-	// Run each test case
-	for _, tc := range testCases {
-		testResult := j.runTestCase(ctx, prob, tc, absSolutionPath, language)
+	// Run test cases, then aggregate in test order so the verdict is deterministic
+	testResults := j.evaluate(ctx, sub, testCases, policy)
+	for _, testResult := range testResults {
 		result.TestResults = append(result.TestResults, testResult)
 		result.TotalDuration += testResult.Duration
 
 		if testResult.Verdict == runner.VerdictAccepted {
 			result.Passed++
+		} else if testResult.Verdict == runner.VerdictSkipped {
+			result.Skipped++
 		} else if result.FinalVerdict == runner.VerdictAccepted {
 			// First non-AC verdict becomes the final verdict
 			result.FinalVerdict = testResult.Verdict
 		}
 	}
 
+	// Report the tests that finished before an interruption, unscored
+	if err := ctx.Err(); err != nil {
+		j.emitDone(sub, result)
+		return result, err
+	}
+
+	// Score subtasks; one missing a test the selection left out is not scored
+	if len(sub.problem.Subtasks) > 0 {
+		result.Subtasks, err = scoreSubtasks(sub.problem.Subtasks, result.TestResults, sub.unselected)
+		if err != nil {
+			return nil, err
+		}
+		for _, st := range result.Subtasks {
+			if st.Incomplete {
+				continue
+			}
+			result.Score += st.Score
+			result.MaxScore += st.Points
+		}
+	}
+
+	j.emitDone(sub, result)
 	return result, nil
 }
 
-// runTestCase runs a single test case and returns the result
-func (j *Judge) runTestCase(ctx context.Context, prob *problem.Problem, tc problem.TestCase, solutionPath, language string) TestResult {
+// runTestCases runs test cases on a bounded worker pool.
+// Results are returned in the same order as testCases. With failFast,
+// tests are no longer started once one has failed, and every test after
+// the first failure (in test order) is reported as skipped.
+func (j *Judge) runTestCases(ctx context.Context, sub *submission, testCases []problem.TestCase, failFast bool) []TestResult {
+	results := make([]TestResult, len(testCases))
+	started := make([]bool, len(testCases))
+	var failed atomic.Bool
+
+	// Each slot is a worker; a test starts only once a slot is free, so
+	// fail-fast sees the outcome of every test that finished before it
+	slots := make(chan struct{}, j.workers(sub.problem, len(testCases)))
+	var wg sync.WaitGroup
+	for i := range testCases {
+		slots <- struct{}{}
+		if (failFast && failed.Load()) || ctx.Err() != nil {
+			<-slots
+			break
+		}
+
+		started[i] = true
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			j.emitTest(sub, EventTestStart, testCases[i], nil)
+			results[i] = j.runTestCase(ctx, sub, testCases[i])
+			if ctx.Err() != nil && results[i].Verdict != runner.VerdictAccepted {
+				// A run killed by cancellation has no meaningful verdict
+				results[i] = skippedResult(testCases[i])
+			}
+			j.emitTest(sub, EventTestEnd, testCases[i], &results[i])
+			if isFailure(results[i].Verdict) {
+				failed.Store(true)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for i := range results {
+		if !started[i] {
+			results[i] = skippedResult(testCases[i])
+			j.emitTest(sub, EventTestEnd, testCases[i], &results[i])
+		}
+	}
+
+	if failFast {
+		// Tests dispatched after the first failure may have finished anyway;
+		// skip them all so the outcome does not depend on scheduling
+		for i := range results {
+			if isFailure(results[i].Verdict) {
+				for k := i + 1; k < len(results); k++ {
+					results[k] = skippedResult(testCases[k])
+				}
+				break
+			}
+		}
+	}
+
+	return results
+}
+
+// workers returns how many test cases may run at once for a problem
+func (j *Judge) workers(prob *problem.Problem, numTests int) int {
+	// Timing-sensitive problems opt out of parallelism
+	if prob.Serial {
+		return 1
+	}
+
+	n := j.jobs
+	if n <= 0 {
+		// Leave a core for the judge and Docker daemon
+		n = runtime.NumCPU() - 1
+	}
+	if n > numTests {
+		n = numTests
+	}
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+// runTestCase runs a single test case and returns the result, with the
+// input and outputs of hidden tests left out
+func (j *Judge) runTestCase(ctx context.Context, sub *submission, tc problem.TestCase) TestResult {
+	timeLimit := j.testTimeLimit(sub, tc)
+	result := j.executeTestCase(ctx, sub, tc, timeLimit)
+	result.TimeLimit = timeLimit
+
+	if tc.Meta.Hidden {
+		redactHidden(&result)
+	}
+	return result
+}
+
+// testTimeLimit returns the time limit of a test case, which may override
+// the problem's base limit
+func (j *Judge) testTimeLimit(sub *submission, tc problem.TestCase) time.Duration {
+	if tc.Meta.TimeLimitMS > 0 {
+		return j.scaleTimeLimit(sub.problem, sub.language, time.Duration(tc.Meta.TimeLimitMS)*time.Millisecond, false)
+	}
+	return sub.timeLimit
+}
+
+// redactHidden removes the input and outputs of a hidden test from its
+// result, including the comparator's message, which may quote them
+func redactHidden(r *TestResult) {
+	r.TestCase = redactTestCase(r.TestCase)
+	r.Expected = ""
+	r.Actual = ""
+	r.Message = ""
+}
+
+// redactTestCase returns a test case without its input and expected outputs
+func redactTestCase(tc problem.TestCase) problem.TestCase {
+	tc.Input = ""
+	tc.Expected = ""
+	tc.Alternatives = nil
+	return tc
+}
+
+// executeTestCase runs the solution on a test case and judges its output
+func (j *Judge) executeTestCase(ctx context.Context, sub *submission, tc problem.TestCase, timeLimit time.Duration) TestResult {
+	memoryLimitMB := sub.problem.MemoryLimitMB
+	if tc.Meta.MemoryLimitMB > 0 {
+		memoryLimitMB = tc.Meta.MemoryLimitMB
+	}
+
 	// Configure the run
 	cfg := runner.RunConfig{
-		Language:    language,
-		SourcePath:  solutionPath,
-		Stdin:       tc.Input,
-		TimeLimit:   time.Duration(prob.TimeLimitMS) * time.Millisecond,
-		MemoryLimit: int64(prob.MemoryLimitMB) * 1024 * 1024,
+		Language:     sub.language,
+		SourcePath:   sub.sourcePath,
+		Stdin:        tc.Input,
+		TimeLimit:    timeLimit,
+		MemoryLimit:  int64(memoryLimitMB) * 1024 * 1024,
+		SampleMemory: sub.sampleMemory,
 	}
 
 	// Run the solution
@@ -171,6 +513,7 @@ func (j *Judge) runTestCase(ctx context.Context, prob *problem.Problem, tc probl
 			TestCase: tc,
 			Verdict:  runResult.Verdict,
 			Duration: runResult.Duration,
+			Memory:   runResult.MemoryUsed,
 			Expected: tc.Expected,
 			Actual:   runResult.Stdout,
 			Error:    runResult.Stderr,
@@ -178,74 +521,127 @@ func (j *Judge) runTestCase(ctx context.Context, prob *problem.Problem, tc probl
 	}
 
 	// Compare output
-	comparison := j.comparator.Compare(tc.Expected, runResult.Stdout)
+	comparison, err := j.judgeOutput(ctx, sub.comparator, tc, runResult.Stdout)
+	if err != nil {
+		return TestResult{
+			TestCase: tc,
+			Verdict:  runner.VerdictSystemError,
+			Duration: runResult.Duration,
+			Expected: tc.Expected,
+			Actual:   runResult.Stdout,
+			Error:    err.Error(),
+		}
+	}
 
-	verdict := runner.VerdictAccepted
-	if !comparison.Match {
-		verdict = runner.VerdictWrongAnswer
+	verdict, score := runner.VerdictAccepted, 1.0
+	if comparison.PresentationError {
+		verdict, score = runner.VerdictPresentationError, 0
+	} else if !comparison.Match {
+		verdict, score = runner.VerdictWrongAnswer, comparison.Score
 	}
 
 	return TestResult{
 		TestCase: tc,
 		Verdict:  verdict,
 		Duration: runResult.Duration,
+		Memory:   runResult.MemoryUsed,
 		Expected: comparison.Expected,
 		Actual:   comparison.Actual,
+		Message:  comparison.Message,
+		Score:    score,
 	}
 }
 
-// RunSingleTest runs only a specific test case by number (1-indexed)
-func (j *Judge) RunSingleTest(ctx context.Context, problemID, solutionPath string, testNum int) (*Result, error) {
-	// Load the problem
-	prob, err := j.problemLoader.Load(problemID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to load problem %s: %w", problemID, err)
-	}
+// compareOutput checks actual output against a test's expected output
+func (j *Judge) compareOutput(ctx context.Context, comp outputChecker, tc problem.TestCase, actual string) (compare.Result, error) {
+	return comp.Check(ctx, tc.Input, tc.Expected, actual)
+}
 
-	// Load test cases
-	testCases, err := j.problemLoader.LoadTestCases(problemID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to load test cases: %w", err)
+// judgeOutput compares actual output against a test's expected output and
+// its alternatives. The first match wins; without one, the comparison with
+// the closest answer is returned so the diff shown is the most useful.
+func (j *Judge) judgeOutput(ctx context.Context, comp outputChecker, tc problem.TestCase, actual string) (compare.Result, error) {
+	best, err := j.compareOutput(ctx, comp, tc, actual)
+	if err != nil || best.Match {
+		return best, err
 	}
 
-	if testNum < 1 || testNum > len(testCases) {
-		return nil, fmt.Errorf("test %d does not exist (problem has %d tests)", testNum, len(testCases))
+	for _, alt := range tc.Alternatives {
+		altCase := tc
+		altCase.Expected = alt
+		r, err := j.compareOutput(ctx, comp, altCase, actual)
+		if err != nil {
+			return r, err
+		}
+		if r.Match {
+			return r, nil
+		}
+		if closer(r, best) {
+			best = r
+		}
 	}
+	return best, nil
+}
 
-	// Get absolute path for solution
-	absSolutionPath, err := filepath.Abs(solutionPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to resolve solution path: %w", err)
+// closer reports whether mismatch a is closer to the actual output than b:
+// a presentation error beats a wrong answer, then higher partial credit,
+// fewer missing or extra lines, and a later first differing line win
+func closer(a, b compare.Result) bool {
+	if a.PresentationError != b.PresentationError {
+		return a.PresentationError
 	}
-
-	// Detect language
-	ext := filepath.Ext(solutionPath)
-	language := extensionToLanguage(ext)
-	if language == "" {
-		return nil, fmt.Errorf("unsupported file extension: %s", ext)
+	if a.Score != b.Score {
+		return a.Score > b.Score
 	}
+	if da, db := len(a.Missing)+len(a.Extra), len(b.Missing)+len(b.Extra); da != db {
+		return da < db
+	}
+	return a.DiffLine > b.DiffLine
+}
 
-	// Run single test
-	tc := testCases[testNum-1]
-	testResult := j.runTestCase(ctx, prob, tc, absSolutionPath, language)
+// effectiveTimeLimit scales a base time limit for a language.
+// The judge-wide override (--timeout) wins; otherwise a per-language
+// override from problem.yaml applies, then the runner's language config.
+func (j *Judge) effectiveTimeLimit(prob *problem.Problem, language string, base time.Duration) time.Duration {
+	return j.scaleTimeLimit(prob, language, base, true)
+}
 
-	result := &Result{
-		ProblemID:     problemID,
-		TestResults:   []TestResult{testResult},
-		FinalVerdict:  testResult.Verdict,
-		TotalDuration: testResult.Duration,
-		Total:         1,
+// scaleTimeLimit applies the language's limits to base, in the order of
+// effectiveTimeLimit. Without absolute, only multipliers apply: a test's own
+// time_limit_ms wins over absolute language limits, and a language whose
+// problem override is absolute (e.g. "python: 2500ms") keeps base as is.
+func (j *Judge) scaleTimeLimit(prob *problem.Problem, language string, base time.Duration, absolute bool) time.Duration {
+	if j.timeLimit > 0 {
+		return j.timeLimit
 	}
 
-	if testResult.Verdict == runner.VerdictAccepted {
-		result.Passed = 1
+	if override, ok := prob.TimeLimitOverrides[language]; ok {
+		if override.Absolute > 0 && !absolute {
+			return base
+		}
+		return override.Apply(base)
 	}
 
-	return result, nil
+	if langConfig, ok := j.runner.Language(language); ok {
+		if langConfig.TimeLimit > 0 && absolute {
+			return langConfig.TimeLimit
+		}
+		if langConfig.TimeMultiplier > 0 {
+			return time.Duration(float64(base) * langConfig.TimeMultiplier)
+		}
+	}
+
+	return base
 }
 
 // Close releases resources held by the Judge
 func (j *Judge) Close() error {
+	j.wrapMu.Lock()
+	if j.wrapDir != "" {
+		os.RemoveAll(j.wrapDir)
+		j.wrapDir = ""
+	}
+	j.wrapMu.Unlock()
 	return j.runner.Cleanup()
 }
 
@@ -0,0 +1,650 @@
+package judge
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/marv972228/sandbox_judge/internal/problem"
+	"github.com/marv972228/sandbox_judge/internal/runner"
+)
+
+// fakeRunner returns canned results and records the configs it was given
+type fakeRunner struct {
+	run play
+
+	// languages replaces runner.DefaultLanguageConfigs if set
+	languages map[string]runner.LanguageConfig
+
+	mu      sync.Mutex
+	configs []runner.RunConfig
+}
+
+func (f *fakeRunner) Run(ctx context.Context, cfg runner.RunConfig) (*runner.RunResult, error) {
+	f.mu.Lock()
+	f.configs = append(f.configs, cfg)
+	f.mu.Unlock()
+	return f.run(cfg), nil
+}
+
+func (f *fakeRunner) Supported() []string { return []string{"python"} }
+
+func (f *fakeRunner) Language(name string) (runner.LanguageConfig, bool) {
+	if f.languages != nil {
+		cfg, ok := f.languages[name]
+		return cfg, ok
+	}
+	cfg, ok := runner.DefaultLanguageConfigs[name]
+	return cfg, ok
+}
+
+func (f *fakeRunner) Cleanup() error { return nil }
+
+// echoRunner "solves" every test by printing its stdin
+func echoRunner(cfg runner.RunConfig) *runner.RunResult {
+	return &runner.RunResult{Verdict: runner.VerdictAccepted, Stdout: cfg.Stdin, Duration: time.Millisecond}
+}
+
+// play is how a fake program responds to one run
+type play func(cfg runner.RunConfig) *runner.RunResult
+
+// programs plays each program by the base name of its source file, so a
+// test only states how the programs it names behave. Programs without an
+// entry echo their input like echoRunner.
+type programs map[string]play
+
+func (p programs) run(cfg runner.RunConfig) *runner.RunResult {
+	if play, ok := p[filepath.Base(cfg.SourcePath)]; ok {
+		return play(cfg)
+	}
+	return echoRunner(cfg)
+}
+
+// byInput plays the response for each listed input and echoes any other
+func byInput(responses map[string]play) play {
+	return func(cfg runner.RunConfig) *runner.RunResult {
+		if respond, ok := responses[cfg.Stdin]; ok {
+			return respond(cfg)
+		}
+		return echoRunner(cfg)
+	}
+}
+
+// prints answers every input with out
+func prints(out string) play {
+	return func(runner.RunConfig) *runner.RunResult {
+		return &runner.RunResult{Verdict: runner.VerdictAccepted, Stdout: out, Duration: time.Millisecond}
+	}
+}
+
+// printsArg prints the program's i-th argument, like a generator its seed
+func printsArg(i int) play {
+	return func(cfg runner.RunConfig) *runner.RunResult {
+		return &runner.RunResult{Verdict: runner.VerdictAccepted, Stdout: cfg.Args[i] + "\n"}
+	}
+}
+
+// timesOut runs past the time limit
+func timesOut(cfg runner.RunConfig) *runner.RunResult {
+	return &runner.RunResult{Verdict: runner.VerdictTimeLimitExceeded, Duration: cfg.TimeLimit}
+}
+
+// exitWith exits with code and writes msg to stderr
+func exitWith(code int, msg string) play {
+	return func(runner.RunConfig) *runner.RunResult {
+		verdict := runner.VerdictAccepted
+		if code != 0 {
+			verdict = runner.VerdictRuntimeError
+		}
+		return &runner.RunResult{Verdict: verdict, ExitCode: code, Stderr: msg}
+	}
+}
+
+// writeProblem creates a problem directory with the given problem.yaml and
+// test files (keyed by path relative to tests/, e.g. "sample/1.in")
+func writeProblem(t *testing.T, id, yaml string, files map[string]string) *problem.Loader {
+	t.Helper()
+
+	dir := t.TempDir()
+	problemDir := filepath.Join(dir, id)
+	for name, content := range files {
+		path := filepath.Join(problemDir, "tests", name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(problemDir, "problem.yaml"), []byte(yaml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return problem.NewLoader(dir)
+}
+
+// echoTests returns test files whose expected output equals their input,
+// except for the names in wrong, whose expected output differs
+func echoTests(names []string, wrong ...string) map[string]string {
+	files := make(map[string]string)
+	for _, name := range names {
+		files[name+".in"] = name + "\n"
+		files[name+".out"] = name + "\n"
+	}
+	for _, name := range wrong {
+		files[name+".out"] = "something else\n"
+	}
+	return files
+}
+
+func TestRun_ParallelKeepsTestOrder(t *testing.T) {
+	names := []string{"sample/1", "sample/2", "hidden/1", "hidden/2", "hidden/3", "hidden/4"}
+	loader := writeProblem(t, "p", "id: p\n", echoTests(names, "hidden/2", "hidden/4"))
+
+	// Earlier tests take longer, so they finish last
+	delays := make(map[string]time.Duration)
+	for i, name := range names {
+		delays[name+"\n"] = time.Duration(len(names)-i) * 5 * time.Millisecond
+	}
+	fr := &fakeRunner{run: func(cfg runner.RunConfig) *runner.RunResult {
+		time.Sleep(delays[cfg.Stdin])
+		return echoRunner(cfg)
+	}}
+	j := &Judge{problemLoader: loader, runner: fr, jobs: 4}
+
+	result, err := j.Run(context.Background(), "p", "solution.py", Selection{})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	for i, tr := range result.TestResults {
+		if tr.TestCase.Name != names[i] {
+			t.Errorf("result %d is %s, want %s", i, tr.TestCase.Name, names[i])
+		}
+	}
+	if result.Passed != 4 || result.Total != 6 {
+		t.Errorf("Passed/Total = %d/%d, want 4/6", result.Passed, result.Total)
+	}
+	if result.FinalVerdict != runner.VerdictWrongAnswer {
+		t.Errorf("FinalVerdict = %s, want WA", result.FinalVerdict)
+	}
+	if len(fr.configs) != 6 {
+		t.Errorf("runner called %d times, want 6", len(fr.configs))
+	}
+	for _, cfg := range fr.configs {
+		if cfg.SampleMemory {
+			t.Fatal("plain runs must not sample memory")
+		}
+	}
+}
+
+func TestWorkers(t *testing.T) {
+	j := &Judge{jobs: 8}
+
+	if got := j.workers(&problem.Problem{}, 3); got != 3 {
+		t.Errorf("workers capped by test count = %d, want 3", got)
+	}
+	if got := j.workers(&problem.Problem{}, 20); got != 8 {
+		t.Errorf("workers with jobs=8 = %d, want 8", got)
+	}
+	if got := j.workers(&problem.Problem{Serial: true}, 20); got != 1 {
+		t.Errorf("workers for serial problem = %d, want 1", got)
+	}
+
+	j.jobs = 0
+	if got := j.workers(&problem.Problem{}, 100); got < 1 {
+		t.Errorf("default workers = %d, want at least 1", got)
+	}
+}
+
+// verdicts returns the verdict of each test result
+func verdicts(results []TestResult) []runner.Verdict {
+	v := make([]runner.Verdict, len(results))
+	for i, r := range results {
+		v[i] = r.Verdict
+	}
+	return v
+}
+
+func TestRun_FailFast(t *testing.T) {
+	names := []string{"sample/1", "hidden/1", "hidden/2", "hidden/3"}
+	loader := writeProblem(t, "p", "id: p\n", echoTests(names, "hidden/1"))
+
+	for _, jobs := range []int{1, 3} {
+		fr := &fakeRunner{run: echoRunner}
+		j := &Judge{problemLoader: loader, runner: fr, jobs: jobs, policy: PolicyFailFast}
+
+		result, err := j.Run(context.Background(), "p", "solution.py", Selection{})
+		if err != nil {
+			t.Fatalf("Run returned error: %v", err)
+		}
+
+		want := []runner.Verdict{runner.VerdictAccepted, runner.VerdictWrongAnswer, runner.VerdictSkipped, runner.VerdictSkipped}
+		got := verdicts(result.TestResults)
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("jobs=%d: verdicts = %v, want %v", jobs, got, want)
+			}
+		}
+		if result.FinalVerdict != runner.VerdictWrongAnswer || result.Passed != 1 || result.Skipped != 2 {
+			t.Errorf("jobs=%d: got %s with %d passed, %d skipped", jobs, result.FinalVerdict, result.Passed, result.Skipped)
+		}
+		if jobs == 1 && len(fr.configs) != 2 {
+			t.Errorf("serial fail-fast ran %d tests, want 2", len(fr.configs))
+		}
+	}
+}
+
+func TestRun_SamplesFirst(t *testing.T) {
+	names := []string{"sample/1", "sample/2", "hidden/1", "hidden/2"}
+
+	// A failing sample skips every hidden test
+	loader := writeProblem(t, "p", "id: p\n", echoTests(names, "sample/2"))
+	fr := &fakeRunner{run: echoRunner}
+	j := &Judge{problemLoader: loader, runner: fr, jobs: 2, policy: PolicySamplesFirst}
+
+	result, err := j.Run(context.Background(), "p", "solution.py", Selection{})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	want := []runner.Verdict{runner.VerdictAccepted, runner.VerdictWrongAnswer, runner.VerdictSkipped, runner.VerdictSkipped}
+	got := verdicts(result.TestResults)
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("verdicts = %v, want %v", got, want)
+		}
+	}
+	if len(fr.configs) != 2 {
+		t.Errorf("ran %d tests, want only the 2 samples", len(fr.configs))
+	}
+
+	// Passing samples run every hidden test, even after a hidden failure
+	loader = writeProblem(t, "p", "id: p\n", echoTests(names, "hidden/1"))
+	j = &Judge{problemLoader: loader, runner: &fakeRunner{run: echoRunner}, jobs: 2, policy: PolicySamplesFirst}
+
+	result, err = j.Run(context.Background(), "p", "solution.py", Selection{})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if result.Skipped != 0 || result.Passed != 3 || result.FinalVerdict != runner.VerdictWrongAnswer {
+		t.Errorf("got %s with %d passed, %d skipped; want WA with 3 passed, 0 skipped",
+			result.FinalVerdict, result.Passed, result.Skipped)
+	}
+}
+
+func TestParsePolicy(t *testing.T) {
+	for _, p := range Policies {
+		got, err := ParsePolicy(string(p))
+		if err != nil || got != p {
+			t.Errorf("ParsePolicy(%q) = %q, %v", p, got, err)
+		}
+	}
+	if got, _ := ParsePolicy(""); got != PolicyAll {
+		t.Errorf("ParsePolicy(\"\") = %q, want all", got)
+	}
+	if _, err := ParsePolicy("sometimes"); err == nil {
+		t.Error("Expected error for unknown policy")
+	}
+}
+
+func TestRun_TestMetadata(t *testing.T) {
+	files := echoTests([]string{"sample/1", "hidden/1", "hidden/2"}, "hidden/2")
+	files["hidden/1.yaml"] = "description: max n\ntime_limit_ms: 3000\n"
+	yaml := `id: p
+time_limit_ms: 1000
+memory_limit_mb: 256
+tests:
+  "hidden/*":
+    hidden: true
+  hidden/2:
+    description: all negative
+    memory_limit_mb: 512
+`
+	loader := writeProblem(t, "p", yaml, files)
+	fr := &fakeRunner{run: echoRunner}
+	j := &Judge{problemLoader: loader, runner: fr, jobs: 1}
+
+	result, err := j.Run(context.Background(), "p", "solution.py", Selection{})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	prob := &problem.Problem{TimeLimitMS: 1000}
+	if got, want := result.TestResults[0].TimeLimit, j.effectiveTimeLimit(prob, "python", time.Second); got != want {
+		t.Errorf("sample/1 time limit = %v, want %v", got, want)
+	}
+	if got, want := result.TestResults[1].TimeLimit, j.effectiveTimeLimit(prob, "python", 3*time.Second); got != want {
+		t.Errorf("hidden/1 time limit = %v, want %v", got, want)
+	}
+	if got := result.TestResults[1].TestCase.Meta.Description; got != "max n" {
+		t.Errorf("hidden/1 description = %q, want sidecar description", got)
+	}
+
+	failed := result.TestResults[2]
+	if failed.TestCase.Meta.Description != "all negative" {
+		t.Errorf("hidden/2 description = %q, want %q", failed.TestCase.Meta.Description, "all negative")
+	}
+	if failed.Expected != "" || failed.Actual != "" || failed.TestCase.Input != "" {
+		t.Errorf("hidden test leaked data: %+v", failed)
+	}
+	if got := fr.configs[2].MemoryLimit; got != 512*1024*1024 {
+		t.Errorf("hidden/2 memory limit = %d, want 512 MB", got)
+	}
+	if got := fr.configs[0].MemoryLimit; got != 256*1024*1024 {
+		t.Errorf("sample/1 memory limit = %d, want 256 MB", got)
+	}
+}
+
+func TestRun_EventsRedactHidden(t *testing.T) {
+	files := echoTests([]string{"hidden/1", "hidden/2"}, "hidden/1")
+	yaml := "id: p\ntests:\n  \"hidden/*\": {hidden: true}\n"
+	loader := writeProblem(t, "p", yaml, files)
+
+	var events []Event
+	j := &Judge{
+		problemLoader: loader,
+		runner:        &fakeRunner{run: echoRunner},
+		jobs:          1,
+		policy:        PolicyFailFast,
+		observer:      ObserverFunc(func(e Event) { events = append(events, e) }),
+	}
+	if _, err := j.Run(context.Background(), "p", "solution.py", Selection{}); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	for _, e := range events {
+		if e.TestCase.Input != "" || e.TestCase.Expected != "" {
+			t.Errorf("%s event for %s leaked the test case", e.Type, e.TestCase.Name)
+		}
+		if e.TestResult != nil && (e.TestResult.TestCase.Input != "" || e.TestResult.Expected != "") {
+			t.Errorf("%s event for %s leaked the result", e.Type, e.TestCase.Name)
+		}
+	}
+}
+
+func TestRun_HiddenMessageRedacted(t *testing.T) {
+	files := echoTests([]string{"sample/1", "hidden/1"}, "sample/1", "hidden/1")
+	yaml := "id: p\ncomparison: unordered\ntests:\n  hidden/1: {hidden: true}\n"
+	loader := writeProblem(t, "p", yaml, files)
+	j := &Judge{problemLoader: loader, runner: &fakeRunner{run: echoRunner}}
+
+	result, err := j.Run(context.Background(), "p", "solution.py", Selection{})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	// The unordered comparator quotes the missing expected lines
+	sample, hidden := result.TestResults[0], result.TestResults[1]
+	if !strings.Contains(sample.Message, "something else") {
+		t.Errorf("sample/1 message = %q, want the missing line quoted", sample.Message)
+	}
+	if hidden.Verdict != runner.VerdictWrongAnswer || hidden.Message != "" {
+		t.Errorf("hidden/1 = %s with message %q, want WA without a message", hidden.Verdict, hidden.Message)
+	}
+	if api := result.API(); api.Tests[1].Message != "" {
+		t.Errorf("JSON message for hidden/1 = %q, want none", api.Tests[1].Message)
+	}
+}
+
+func TestRun_Events(t *testing.T) {
+	names := []string{"sample/1", "hidden/1", "hidden/2", "hidden/3"}
+	loader := writeProblem(t, "p", "id: p\n", echoTests(names, "hidden/1"))
+
+	var events []Event
+	j := &Judge{
+		problemLoader: loader,
+		runner:        &fakeRunner{run: echoRunner},
+		jobs:          3,
+		observer:      ObserverFunc(func(e Event) { events = append(events, e) }),
+	}
+
+	result, err := j.Run(context.Background(), "p", "solution.py", Selection{})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	if len(events) != 2+2*len(names)+1 {
+		t.Fatalf("got %d events, want %d", len(events), 2+2*len(names)+1)
+	}
+	if events[0].Type != EventCompileStart || events[1].Type != EventCompileEnd {
+		t.Errorf("first events = %s, %s; want compile start and end", events[0].Type, events[1].Type)
+	}
+	if last := events[len(events)-1]; last.Type != EventRunDone || last.Result != result {
+		t.Errorf("last event = %+v, want run-done with the final result", last)
+	}
+
+	started := make(map[int]bool)
+	for _, e := range events[2 : len(events)-1] {
+		if e.Total != len(names) || e.TestCase.Name != names[e.Index] {
+			t.Errorf("event %s has index %d for %s", e.Type, e.Index, e.TestCase.Name)
+		}
+		switch e.Type {
+		case EventTestStart:
+			started[e.Index] = true
+		case EventTestEnd:
+			if !started[e.Index] {
+				t.Errorf("%s ended before it started", e.TestCase.Name)
+			}
+			if e.TestResult.Verdict != result.TestResults[e.Index].Verdict {
+				t.Errorf("%s ended with %s, result says %s", e.TestCase.Name, e.TestResult.Verdict, result.TestResults[e.Index].Verdict)
+			}
+		default:
+			t.Errorf("unexpected event %s", e.Type)
+		}
+	}
+}
+
+func TestRun_EventsForSkippedTests(t *testing.T) {
+	names := []string{"sample/1", "hidden/1", "hidden/2"}
+	loader := writeProblem(t, "p", "id: p\n", echoTests(names, "sample/1"))
+
+	ends := make(map[string]runner.Verdict)
+	j := &Judge{
+		problemLoader: loader,
+		runner:        &fakeRunner{run: echoRunner},
+		policy:        PolicySamplesFirst,
+		observer: ObserverFunc(func(e Event) {
+			if e.Type == EventTestEnd {
+				ends[e.TestCase.Name] = e.TestResult.Verdict
+			}
+		}),
+	}
+
+	if _, err := j.Run(context.Background(), "p", "solution.py", Selection{}); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	for _, name := range names[1:] {
+		if ends[name] != runner.VerdictSkipped {
+			t.Errorf("%s ended with %q, want SKIP", name, ends[name])
+		}
+	}
+}
+
+func TestRun_AlternativeOutputs(t *testing.T) {
+	files := map[string]string{
+		"sample/1.in":       "a\n",
+		"sample/1.out":      "1\n2\n3\n",
+		"sample/1.alt1.out": "1\n5\n9\n",
+		"sample/1.alt2.out": "3\n2\n1\n",
+		"sample/2.in":       "b\n",
+		"sample/2.out":      "1\n2\n3\n",
+		"sample/2.alt1.out": "1\n5\n9\n",
+	}
+	loader := writeProblem(t, "p", "id: p\ntime_limit_ms: 1000\nmemory_limit_mb: 256\n", files)
+	fr := &fakeRunner{run: func(cfg runner.RunConfig) *runner.RunResult {
+		out := map[string]string{"a\n": "3\n2\n1\n", "b\n": "1\n5\n7\n"}[cfg.Stdin]
+		return &runner.RunResult{Verdict: runner.VerdictAccepted, Stdout: out}
+	}}
+	j := &Judge{problemLoader: loader, runner: fr, jobs: 1}
+
+	result, err := j.Run(context.Background(), "p", "solution.py", Selection{})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	if got := result.TestResults[0].Verdict; got != runner.VerdictAccepted {
+		t.Errorf("sample/1 verdict = %s, want AC (matches alt2)", got)
+	}
+
+	wa := result.TestResults[1]
+	if wa.Verdict != runner.VerdictWrongAnswer {
+		t.Fatalf("sample/2 verdict = %s, want WA", wa.Verdict)
+	}
+	// alt1 agrees on two lines, the main answer on one
+	if wa.Expected != "1\n5\n9" {
+		t.Errorf("sample/2 expected = %q, want the closest alternative", wa.Expected)
+	}
+}
+
+func TestRun_Cancelled(t *testing.T) {
+	loader := writeProblem(t, "p", "id: p\n", echoTests([]string{"sample/1", "sample/2", "sample/3"}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	fr := &fakeRunner{run: func(cfg runner.RunConfig) *runner.RunResult {
+		cancel() // The solution was edited while the first test ran
+		return echoRunner(cfg)
+	}}
+	var last Event
+	j := &Judge{problemLoader: loader, runner: fr, jobs: 1, observer: ObserverFunc(func(e Event) { last = e })}
+
+	result, err := j.Run(ctx, "p", "solution.py", Selection{})
+	if err != context.Canceled || result == nil {
+		t.Fatalf("Run = %v, %v; want partial result and context.Canceled", result, err)
+	}
+	if len(fr.configs) != 1 {
+		t.Errorf("%d tests started after cancellation, want none", len(fr.configs)-1)
+	}
+	// The first test finished (AC); the others never started
+	if result.Passed != 1 || result.Skipped != 2 || result.Total != 3 {
+		t.Errorf("passed %d, skipped %d of %d; want 1, 2 of 3", result.Passed, result.Skipped, result.Total)
+	}
+	if result.FinalVerdict != runner.VerdictAccepted {
+		t.Errorf("FinalVerdict = %s, want AC from the finished tests", result.FinalVerdict)
+	}
+	if last.Type != EventRunDone || last.Result != result {
+		t.Errorf("last event = %s, want run-done with the partial result", last.Type)
+	}
+}
+
+func TestRun_CancelledRunIsSkipped(t *testing.T) {
+	loader := writeProblem(t, "p", "id: p\n", echoTests([]string{"sample/1", "sample/2"}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	fr := &fakeRunner{run: func(cfg runner.RunConfig) *runner.RunResult {
+		if cfg.Stdin == "sample/2\n" {
+			cancel() // The container is killed mid-run
+			return &runner.RunResult{Verdict: runner.VerdictRuntimeError, ExitCode: 137}
+		}
+		return echoRunner(cfg)
+	}}
+	j := &Judge{problemLoader: loader, runner: fr, jobs: 1}
+
+	result, err := j.Run(ctx, "p", "solution.py", Selection{})
+	if err != context.Canceled || result == nil {
+		t.Fatalf("Run = %v, %v; want partial result and context.Canceled", result, err)
+	}
+	if v := result.TestResults[1].Verdict; v != runner.VerdictSkipped {
+		t.Errorf("killed test verdict = %s, want SKIP", v)
+	}
+	if result.FinalVerdict != runner.VerdictAccepted {
+		t.Errorf("FinalVerdict = %s, want AC", result.FinalVerdict)
+	}
+}
+
+func TestPrepareSolution_SkipsTestCases(t *testing.T) {
+	// Without a builder, loading the generated tests fails
+	yaml := "id: p\nreference: ref.py\ngenerated:\n  - {generator: gen.py, seeds: [1]}\n"
+	files := map[string]string{"../ref.py": "", "../gen.py": ""}
+	loader := writeProblem(t, "p", yaml, files)
+	fr := &fakeRunner{run: echoRunner}
+	j := &Judge{problemLoader: loader, runner: fr}
+
+	if _, _, err := j.prepare(context.Background(), "p", "solution.py", Selection{}); err == nil {
+		t.Fatal("prepare loaded the unbuilt generated tests")
+	}
+	if _, err := j.Try(context.Background(), "p", "solution.py", "1\n"); err != nil {
+		t.Errorf("Try loaded the test cases: %v", err)
+	}
+	if _, err := j.Stress(context.Background(), "p", "solution.py", StressConfig{GeneratorPath: "g.py", ReferencePath: "r.py", Iterations: 1}); err != nil {
+		t.Errorf("Stress loaded the test cases: %v", err)
+	}
+}
+
+func TestEffectiveTimeLimit(t *testing.T) {
+	fr := &fakeRunner{run: echoRunner, languages: map[string]runner.LanguageConfig{
+		"python": {TimeMultiplier: 3},
+		"java":   {TimeLimit: 4 * time.Second},
+	}}
+	prob := &problem.Problem{TimeLimitOverrides: map[string]problem.TimeLimitOverride{
+		"python": {Multiplier: 2},
+		"go":     {Absolute: 1500 * time.Millisecond},
+	}}
+	base := time.Second
+
+	tests := []struct {
+		name     string
+		timeout  time.Duration
+		language string
+		want     time.Duration
+	}{
+		{"timeout wins over everything", 5 * time.Second, "python", 5 * time.Second},
+		{"problem override wins over language config", 0, "python", 2 * time.Second},
+		{"absolute problem override", 0, "go", 1500 * time.Millisecond},
+		{"language config", 0, "java", 4 * time.Second},
+		{"base", 0, "cpp", time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &Judge{runner: fr, timeLimit: tt.timeout}
+			if got := j.effectiveTimeLimit(prob, tt.language, base); got != tt.want {
+				t.Errorf("effectiveTimeLimit(%s) = %v, want %v", tt.language, got, tt.want)
+			}
+		})
+	}
+
+	// Without overrides, Python gets the default language multiplier
+	j := &Judge{runner: &fakeRunner{run: echoRunner}}
+	if got := j.effectiveTimeLimit(&problem.Problem{}, "python", base); got != 3*time.Second {
+		t.Errorf("default python limit = %v, want 3s", got)
+	}
+}
+
+func TestRun_TestTimeLimitWithAbsoluteOverride(t *testing.T) {
+	files := echoTests([]string{"sample/1", "sample/2"})
+	files["sample/2.yaml"] = "time_limit_ms: 3000\n"
+	yaml := `id: p
+time_limit_ms: 1000
+time_limit_overrides:
+  python: 2500ms
+  go: 2x
+`
+	loader := writeProblem(t, "p", yaml, files)
+
+	tests := []struct {
+		solution         string
+		wantProblemLimit time.Duration
+		wantTestLimit    time.Duration
+	}{
+		// The absolute override replaces the problem limit, not the test's
+		{"solution.py", 2500 * time.Millisecond, 3 * time.Second},
+		// Multipliers scale both
+		{"solution.go", 2 * time.Second, 6 * time.Second},
+	}
+	for _, tt := range tests {
+		j := &Judge{problemLoader: loader, runner: &fakeRunner{run: echoRunner}, jobs: 1}
+		result, err := j.Run(context.Background(), "p", tt.solution, Selection{})
+		if err != nil {
+			t.Fatalf("%s: Run returned error: %v", tt.solution, err)
+		}
+		if got := result.TestResults[0].TimeLimit; got != tt.wantProblemLimit {
+			t.Errorf("%s: sample/1 limit = %v, want %v", tt.solution, got, tt.wantProblemLimit)
+		}
+		if got := result.TestResults[1].TimeLimit; got != tt.wantTestLimit {
+			t.Errorf("%s: sample/2 limit = %v, want %v", tt.solution, got, tt.wantTestLimit)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package judge
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/marv972228/sandbox_judge/internal/problem"
+	"github.com/marv972228/sandbox_judge/internal/runner"
+)
+
+// Policy decides which test cases are run after a failure
+type Policy string
+
+const (
+	PolicyAll          Policy = "all"           // Run every test case
+	PolicyFailFast     Policy = "fail-fast"     // Stop at the first failing test
+	PolicySamplesFirst Policy = "samples-first" // Run samples first, skip hidden tests if any sample fails
+)
+
+// Policies lists the supported evaluation policies
+var Policies = []Policy{PolicyAll, PolicyFailFast, PolicySamplesFirst}
+
+// ParsePolicy converts a policy name to a Policy (empty selects PolicyAll)
+func ParsePolicy(s string) (Policy, error) {
+	if s == "" {
+		return PolicyAll, nil
+	}
+	for _, p := range Policies {
+		if Policy(s) == p {
+			return p, nil
+		}
+	}
+
+	names := make([]string, len(Policies))
+	for i, p := range Policies {
+		names[i] = string(p)
+	}
+	return "", fmt.Errorf("unknown evaluation policy %q (available: %s)", s, strings.Join(names, ", "))
+}
+
+// evaluate runs test cases according to an evaluation policy
+func (j *Judge) evaluate(ctx context.Context, sub *submission, testCases []problem.TestCase, policy Policy) []TestResult {
+	switch policy {
+	case PolicyFailFast:
+		return j.runTestCases(ctx, sub, testCases, true)
+	case PolicySamplesFirst:
+		return j.runSamplesFirst(ctx, sub, testCases)
+	default:
+		return j.runTestCases(ctx, sub, testCases, false)
+	}
+}
+
+// runSamplesFirst runs sample tests as pretests and only runs the
+// remaining tests if every sample passes (Codeforces-style)
+func (j *Judge) runSamplesFirst(ctx context.Context, sub *submission, testCases []problem.TestCase) []TestResult {
+	var samples, others []int
+	for i, tc := range testCases {
+		if tc.Sample {
+			samples = append(samples, i)
+		} else {
+			others = append(others, i)
+		}
+	}
+
+	results := make([]TestResult, len(testCases))
+
+	pretestsPassed := true
+	for k, r := range j.runTestCases(ctx, sub, pick(testCases, samples), false) {
+		results[samples[k]] = r
+		if isFailure(r.Verdict) {
+			pretestsPassed = false
+		}
+	}
+
+	if !pretestsPassed {
+		for _, i := range others {
+			results[i] = skippedResult(testCases[i])
+			j.emitTest(sub, EventTestEnd, testCases[i], &results[i])
+		}
+		return results
+	}
+
+	for k, r := range j.runTestCases(ctx, sub, pick(testCases, others), false) {
+		results[others[k]] = r
+	}
+	return results
+}
+
+// pick returns the test cases at the given indexes
+func pick(testCases []problem.TestCase, indexes []int) []problem.TestCase {
+	picked := make([]problem.TestCase, len(indexes))
+	for k, i := range indexes {
+		picked[k] = testCases[i]
+	}
+	return picked
+}
+
+// isFailure reports whether a verdict counts as a failed test
+func isFailure(v runner.Verdict) bool {
+	return v != runner.VerdictAccepted && v != runner.VerdictSkipped
+}
+
+// skippedResult is the result of a test case that was not run
+func skippedResult(tc problem.TestCase) TestResult {
+	if tc.Meta.Hidden {
+		tc = redactTestCase(tc)
+	}
+	return TestResult{
+		TestCase: tc,
+		Verdict:  runner.VerdictSkipped,
+	}
+}
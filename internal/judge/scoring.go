@@ -0,0 +1,118 @@
+package judge
+
+import (
+	"fmt"
+
+	"github.com/marv972228/sandbox_judge/internal/problem"
+	"github.com/marv972228/sandbox_judge/internal/runner"
+)
+
+// SubtaskResult holds the outcome of one subtask
+type SubtaskResult struct {
+	// Name of the subtask from problem.yaml
+	Name string
+
+	// Score is the number of points earned
+	Score float64
+
+	// Points is the maximum number of points
+	Points float64
+
+	// Passed is the count of AC test cases in the subtask
+	Passed int
+
+	// Total is the number of test cases in the subtask
+	Total int
+
+	// BlockedBy names an unsolved dependency that zeroed the score (empty if none)
+	BlockedBy string
+
+	// Incomplete is true if the selection left out some of the subtask's
+	// test cases (or those of a dependency), so it was not scored
+	Incomplete bool
+}
+
+// Solved reports whether every test case in the subtask passed
+func (s SubtaskResult) Solved() bool {
+	return s.Passed == s.Total
+}
+
+// scoreSubtasks computes the score of each subtask from test results.
+// unselected names the test cases that were not run; a subtask that
+// matches any of them is marked incomplete instead of scored.
+func scoreSubtasks(subtasks []problem.Subtask, results []TestResult, unselected []string) ([]SubtaskResult, error) {
+	scored := make([]SubtaskResult, 0, len(subtasks))
+	byName := make(map[string]SubtaskResult, len(subtasks))
+
+	for _, st := range subtasks {
+		sr := SubtaskResult{
+			Name:   st.Name,
+			Points: st.Points,
+		}
+
+		minScore := 1.0
+		var weighted, totalWeight float64
+		for _, r := range results {
+			if !st.Matches(r.TestCase.Name) {
+				continue
+			}
+			sr.Total++
+			if r.Verdict == runner.VerdictAccepted {
+				sr.Passed++
+			}
+			if r.Score < minScore {
+				minScore = r.Score
+			}
+			weight := r.TestCase.Meta.TestWeight()
+			weighted += weight * r.Score
+			totalWeight += weight
+		}
+
+		for _, name := range unselected {
+			if st.Matches(name) {
+				sr.Incomplete = true
+				break
+			}
+		}
+		for _, dep := range st.DependsOn {
+			if byName[dep].Incomplete {
+				sr.Incomplete = true
+				break
+			}
+		}
+		if sr.Incomplete {
+			scored = append(scored, sr)
+			byName[st.Name] = sr
+			continue
+		}
+
+		if sr.Total == 0 {
+			return nil, fmt.Errorf("subtask %s matches no test cases", st.Name)
+		}
+
+		switch st.Scoring {
+		case problem.ScoringMin:
+			sr.Score = st.Points * minScore
+		case problem.ScoringSum:
+			sr.Score = st.Points * weighted / totalWeight
+		default:
+			if sr.Solved() {
+				sr.Score = st.Points
+			}
+		}
+
+		// Dependencies are declared earlier, so they are already scored
+		for _, dep := range st.DependsOn {
+			if !byName[dep].Solved() {
+				sr.Score = 0
+				sr.BlockedBy = dep
+				break
+			}
+		}
+
+		scored = append(scored, sr)
+		byName[st.Name] = sr
+	}
+
+	return scored, nil
+}
@@ -0,0 +1,145 @@
+package judge
+
+import (
+	"testing"
+
+	"github.com/marv972228/sandbox_judge/internal/problem"
+	"github.com/marv972228/sandbox_judge/internal/runner"
+)
+
+// testResult builds a TestResult with the score implied by its verdict
+func testResult(name string, verdict runner.Verdict) TestResult {
+	r := TestResult{TestCase: problem.TestCase{Name: name}, Verdict: verdict}
+	if verdict == runner.VerdictAccepted {
+		r.Score = 1
+	}
+	return r
+}
+
+func TestScoreSubtasks(t *testing.T) {
+	subtasks := []problem.Subtask{
+		{Name: "samples", Points: 0, Tests: []string{"sample/*"}, Scoring: problem.ScoringAllOrNothing},
+		{Name: "small", Points: 30, Tests: []string{"hidden/1", "hidden/2"}, Scoring: problem.ScoringAllOrNothing},
+		{Name: "large", Points: 70, Tests: []string{"hidden/*"}, DependsOn: []string{"small"}, Scoring: problem.ScoringAllOrNothing},
+	}
+	results := []TestResult{
+		testResult("sample/1", runner.VerdictAccepted),
+		testResult("hidden/1", runner.VerdictAccepted),
+		testResult("hidden/2", runner.VerdictAccepted),
+		testResult("hidden/3", runner.VerdictTimeLimitExceeded),
+	}
+
+	scored, err := scoreSubtasks(subtasks, results, nil)
+	if err != nil {
+		t.Fatalf("scoreSubtasks returned error: %v", err)
+	}
+
+	want := []SubtaskResult{
+		{Name: "samples", Score: 0, Points: 0, Passed: 1, Total: 1},
+		{Name: "small", Score: 30, Points: 30, Passed: 2, Total: 2},
+		{Name: "large", Score: 0, Points: 70, Passed: 2, Total: 3},
+	}
+	for i := range want {
+		if scored[i] != want[i] {
+			t.Errorf("subtask %d = %+v, want %+v", i, scored[i], want[i])
+		}
+	}
+}
+
+func TestScoreSubtasks_Dependency(t *testing.T) {
+	subtasks := []problem.Subtask{
+		{Name: "small", Points: 40, Tests: []string{"hidden/1"}, Scoring: problem.ScoringAllOrNothing},
+		{Name: "large", Points: 60, Tests: []string{"hidden/2"}, DependsOn: []string{"small"}, Scoring: problem.ScoringAllOrNothing},
+	}
+	results := []TestResult{
+		testResult("hidden/1", runner.VerdictWrongAnswer),
+		testResult("hidden/2", runner.VerdictAccepted),
+	}
+
+	scored, err := scoreSubtasks(subtasks, results, nil)
+	if err != nil {
+		t.Fatalf("scoreSubtasks returned error: %v", err)
+	}
+	if scored[1].Score != 0 || scored[1].BlockedBy != "small" {
+		t.Errorf("Expected large to be blocked by small, got %+v", scored[1])
+	}
+}
+
+func TestScoreSubtasks_MinScoring(t *testing.T) {
+	subtasks := []problem.Subtask{
+		{Name: "partial", Points: 50, Tests: []string{"hidden/*"}, Scoring: problem.ScoringMin},
+		{Name: "strict", Points: 50, Tests: []string{"hidden/*"}, Scoring: problem.ScoringAllOrNothing},
+	}
+	half := testResult("hidden/2", runner.VerdictWrongAnswer)
+	half.Score = 0.5
+	results := []TestResult{testResult("hidden/1", runner.VerdictAccepted), half}
+
+	scored, err := scoreSubtasks(subtasks, results, nil)
+	if err != nil {
+		t.Fatalf("scoreSubtasks returned error: %v", err)
+	}
+	if scored[0].Score != 25 {
+		t.Errorf("min scoring = %v, want 25", scored[0].Score)
+	}
+	if scored[1].Score != 0 {
+		t.Errorf("all-or-nothing scoring = %v, want 0", scored[1].Score)
+	}
+}
+
+func TestScoreSubtasks_SumScoring(t *testing.T) {
+	subtasks := []problem.Subtask{{Name: "weighted", Points: 40, Tests: []string{"hidden/*"}, Scoring: problem.ScoringSum}}
+	heavy := testResult("hidden/1", runner.VerdictAccepted)
+	heavy.TestCase.Meta.Weight = 3
+	results := []TestResult{heavy, testResult("hidden/2", runner.VerdictWrongAnswer)}
+
+	scored, err := scoreSubtasks(subtasks, results, nil)
+	if err != nil {
+		t.Fatalf("scoreSubtasks returned error: %v", err)
+	}
+	if scored[0].Score != 30 {
+		t.Errorf("sum scoring = %v, want 30", scored[0].Score)
+	}
+}
+
+func TestScoreSubtasks_NoMatchingTests(t *testing.T) {
+	subtasks := []problem.Subtask{{Name: "ghost", Points: 10, Tests: []string{"extra/*"}}}
+	if _, err := scoreSubtasks(subtasks, []TestResult{testResult("hidden/1", runner.VerdictAccepted)}, nil); err == nil {
+		t.Error("Expected error for subtask without tests")
+	}
+}
+
+func TestParsePartialScore(t *testing.T) {
+	for msg, want := range map[string]float64{"0.5 half right": 0.5, "points 0.25": 0.25, "1": 1} {
+		got, err := parsePartialScore(msg)
+		if err != nil || got != want {
+			t.Errorf("parsePartialScore(%q) = %v, %v; want %v", msg, got, err, want)
+		}
+	}
+	for _, msg := range []string{"", "points", "1.5", "-0.1", "half"} {
+		if _, err := parsePartialScore(msg); err == nil {
+			t.Errorf("parsePartialScore(%q) expected error", msg)
+		}
+	}
+}
+
+func TestScoreSubtasks_Incomplete(t *testing.T) {
+	subtasks := []problem.Subtask{
+		{Name: "small", Points: 40, Tests: []string{"hidden/1"}, Scoring: problem.ScoringAllOrNothing},
+		{Name: "large", Points: 60, Tests: []string{"hidden/2"}, Scoring: problem.ScoringAllOrNothing},
+		{Name: "full", Points: 0, Tests: []string{"hidden/3"}, DependsOn: []string{"large"}, Scoring: problem.ScoringAllOrNothing},
+	}
+	results := []TestResult{
+		testResult("hidden/1", runner.VerdictAccepted),
+		testResult("hidden/3", runner.VerdictAccepted),
+	}
+
+	scored, err := scoreSubtasks(subtasks, results, []string{"hidden/2"})
+	if err != nil {
+		t.Fatalf("scoreSubtasks returned error: %v", err)
+	}
+
+	// A subtask with an unrun dependency cannot be scored either
+	if scored[0].Incomplete || scored[0].Score != 40 || !scored[1].Incomplete || !scored[2].Incomplete {
+		t.Errorf("Unexpected subtasks: %+v", scored)
+	}
+}
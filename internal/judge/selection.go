@@ -0,0 +1,88 @@
+package judge
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/marv972228/sandbox_judge/internal/problem"
+)
+
+// Selection chooses which test cases a run includes. Each entry is a test
+// name ("sample/2"), a pattern ("hidden/*") or a 1-based position in the
+// full test list ("3"). The zero value selects every test.
+type Selection struct {
+	// Include lists the tests to run (empty = all)
+	Include []string
+
+	// Exclude lists tests to leave out, applied after Include
+	Exclude []string
+}
+
+// IsZero reports whether the selection includes every test
+func (s Selection) IsZero() bool {
+	return len(s.Include) == 0 && len(s.Exclude) == 0
+}
+
+// apply returns the selected test cases in their original order. An include
+// entry that matches no test is an error, since it is most likely a typo.
+func (s Selection) apply(testCases []problem.TestCase) ([]problem.TestCase, error) {
+	if s.IsZero() {
+		return testCases, nil
+	}
+
+	for _, entry := range s.Include {
+		found := false
+		for i, tc := range testCases {
+			if selects(entry, i, tc) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no test matches %q (available: %s)", entry, testNames(testCases))
+		}
+	}
+
+	var selected []problem.TestCase
+	for i, tc := range testCases {
+		included := len(s.Include) == 0
+		for _, entry := range s.Include {
+			if selects(entry, i, tc) {
+				included = true
+				break
+			}
+		}
+		for _, entry := range s.Exclude {
+			if selects(entry, i, tc) {
+				included = false
+				break
+			}
+		}
+		if included {
+			selected = append(selected, tc)
+		}
+	}
+
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("the selection excludes every test")
+	}
+	return selected, nil
+}
+
+// selects reports whether a selection entry matches the test at index i
+func selects(entry string, i int, tc problem.TestCase) bool {
+	if n, err := strconv.Atoi(entry); err == nil {
+		return n == i+1
+	}
+	return problem.MatchTestName(entry, tc.Name)
+}
+
+// testNames lists test names for error messages
+func testNames(testCases []problem.TestCase) string {
+	names := make([]string, len(testCases))
+	for i, tc := range testCases {
+		names[i] = tc.Name
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,114 @@
+package judge
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/marv972228/sandbox_judge/internal/problem"
+)
+
+func TestSelection_Apply(t *testing.T) {
+	var testCases []problem.TestCase
+	for _, name := range []string{"sample/1", "sample/2", "hidden/1", "hidden/2", "hidden/10"} {
+		testCases = append(testCases, problem.TestCase{Name: name})
+	}
+
+	tests := []struct {
+		name string
+		sel  Selection
+		want string
+		err  string
+	}{
+		{"zero value", Selection{}, "sample/1 sample/2 hidden/1 hidden/2 hidden/10", ""},
+		{"name", Selection{Include: []string{"sample/2"}}, "sample/2", ""},
+		{"pattern", Selection{Include: []string{"hidden/*"}}, "hidden/1 hidden/2 hidden/10", ""},
+		{"list keeps test order", Selection{Include: []string{"hidden/2", "sample/1"}}, "sample/1 hidden/2", ""},
+		{"index", Selection{Include: []string{"3"}}, "hidden/1", ""},
+		{"exclude only", Selection{Exclude: []string{"sample/*"}}, "hidden/1 hidden/2 hidden/10", ""},
+		{"include and exclude", Selection{Include: []string{"hidden/*"}, Exclude: []string{"hidden/1?"}}, "hidden/1 hidden/2", ""},
+		{"typo", Selection{Include: []string{"hiden/1"}}, "", `no test matches "hiden/1"`},
+		{"index out of range", Selection{Include: []string{"9"}}, "", `no test matches "9"`},
+		{"everything excluded", Selection{Include: []string{"sample/1"}, Exclude: []string{"*/*"}}, "", "excludes every test"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.sel.apply(testCases)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("apply() error = %v, want one containing %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("apply() returned error: %v", err)
+			}
+			names := make([]string, len(got))
+			for i, tc := range got {
+				names[i] = tc.Name
+			}
+			if strings.Join(names, " ") != tt.want {
+				t.Errorf("apply() = %v, want %s", names, tt.want)
+			}
+		})
+	}
+}
+
+func TestRun_Selection(t *testing.T) {
+	loader := writeProblem(t, "p", "id: p\n", echoTests([]string{"sample/1", "sample/2", "hidden/1"}, "hidden/1"))
+	var events []Event
+	j := &Judge{
+		problemLoader: loader,
+		runner:        &fakeRunner{run: echoRunner},
+		observer:      ObserverFunc(func(e Event) { events = append(events, e) }),
+	}
+
+	result, err := j.Run(context.Background(), "p", "solution.py", Selection{Exclude: []string{"sample/1"}})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	if result.Total != 2 || result.Passed != 1 || result.TestResults[0].TestCase.Name != "sample/2" {
+		t.Errorf("Unexpected result: total %d, passed %d, first %s", result.Total, result.Passed, result.TestResults[0].TestCase.Name)
+	}
+	for _, e := range events {
+		if e.Type == EventTestEnd && (e.Total != 2 || e.Index > 1) {
+			t.Errorf("Event for %s has index %d of %d, want positions within the selection", e.TestCase.Name, e.Index, e.Total)
+		}
+	}
+}
+
+func TestRun_SelectionScoresCompleteSubtasks(t *testing.T) {
+	yaml := `id: p
+subtasks:
+  - name: small
+    points: 30
+    tests: ["hidden/1"]
+  - name: large
+    points: 70
+    tests: ["hidden/2"]
+`
+	loader := writeProblem(t, "p", yaml, echoTests([]string{"hidden/1", "hidden/2"}))
+	j := &Judge{problemLoader: loader, runner: &fakeRunner{run: echoRunner}}
+
+	// A selection that leaves nothing out scores like a full run
+	result, err := j.Run(context.Background(), "p", "solution.py", Selection{Exclude: []string{"extra/*"}})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if result.Score != 100 || result.MaxScore != 100 {
+		t.Errorf("Score = %v/%v, want 100/100", result.Score, result.MaxScore)
+	}
+
+	result, err = j.Run(context.Background(), "p", "solution.py", Selection{Exclude: []string{"hidden/2"}})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if small, large := result.Subtasks[0], result.Subtasks[1]; small.Incomplete || small.Score != 30 || !large.Incomplete {
+		t.Errorf("Unexpected subtasks: %+v", result.Subtasks)
+	}
+	if result.Score != 30 || result.MaxScore != 30 {
+		t.Errorf("Score = %v/%v, want 30/30", result.Score, result.MaxScore)
+	}
+}
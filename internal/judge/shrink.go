@@ -0,0 +1,117 @@
+package judge
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/marv972228/sandbox_judge/internal/problem"
+	"github.com/marv972228/sandbox_judge/internal/runner"
+	"github.com/marv972228/sandbox_judge/internal/shrink"
+)
+
+// DefaultShrinkAttempts bounds the candidates tried when no limit is given.
+// Each attempt runs the validator, the reference and the solution.
+const DefaultShrinkAttempts = 500
+
+// ShrinkConfig configures the minimization of a failing test
+type ShrinkConfig struct {
+	// ReferencePath is a trusted solution that prints the expected output
+	// for an input (empty = the problem's reference)
+	ReferencePath string
+
+	// MaxAttempts bounds the candidate inputs tried (0 = DefaultShrinkAttempts)
+	MaxAttempts int
+}
+
+// ShrinkResult holds the outcome of a shrink
+type ShrinkResult struct {
+	// Original is the result of the test as given
+	Original TestResult
+
+	// Minimal is the result on the smallest input that fails the same way.
+	// Its expected output comes from the reference solution.
+	Minimal TestResult
+
+	// Attempts is the number of candidate inputs tried
+	Attempts int
+
+	// Exhausted is true if the attempt limit stopped the shrink early
+	Exhausted bool
+}
+
+// Shrink minimizes the input of a failing test case. A candidate input
+// reproduces the failure if the problem's validator accepts it and the
+// solution gets the same verdict as on the original, judged against the
+// reference's output.
+func (j *Judge) Shrink(ctx context.Context, problemID, solutionPath string, tc problem.TestCase, cfg ShrinkConfig) (*ShrinkResult, error) {
+	sub, err := j.prepareSolution(problemID, solutionPath)
+	if err != nil {
+		return nil, err
+	}
+
+	referencePath := cfg.ReferencePath
+	if referencePath == "" {
+		if sub.problem.Reference == "" {
+			return nil, fmt.Errorf("problem %s has no reference solution (pass one, or add `reference:` to problem.yaml)", problemID)
+		}
+		referencePath = filepath.Join(j.problemLoader.Dir(problemID), sub.problem.Reference)
+	}
+
+	// Shrunk inputs are printed in full, so hidden tests lose their protection
+	tc.Meta.Hidden = false
+
+	original := j.runTestCase(ctx, sub, tc)
+	if original.Verdict == runner.VerdictSystemError {
+		return nil, fmt.Errorf("test %s: %s", tc.Name, original.Error)
+	}
+	if !isFailure(original.Verdict) {
+		return nil, fmt.Errorf("test %s passes (%s), nothing to shrink", tc.Name, original.Verdict)
+	}
+
+	maxAttempts := cfg.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultShrinkAttempts
+	}
+
+	minimal := original
+	reproduces := func(ctx context.Context, input string) (bool, error) {
+		expected, err := j.runReference(ctx, sub.problem, referencePath, input)
+		if err != nil {
+			// The reference cannot handle the input, so it cannot be judged
+			return false, nil
+		}
+
+		// The original's alternative answers belong to a different input
+		candidate := tc
+		candidate.Input = input
+		candidate.Expected = expected
+		candidate.Alternatives = nil
+
+		tr := j.runTestCase(ctx, sub, candidate)
+		if tr.Verdict != original.Verdict {
+			return false, nil
+		}
+		minimal = tr
+		return true, nil
+	}
+	valid := func(ctx context.Context, input string) (bool, error) {
+		ok, _, err := j.validateInput(ctx, sub.problem, input)
+		return ok, err
+	}
+
+	shrunk, err := shrink.Shrink(ctx, tc.Input, reproduces, shrink.Options{
+		Valid:       valid,
+		MaxAttempts: maxAttempts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ShrinkResult{
+		Original:  original,
+		Minimal:   minimal,
+		Attempts:  shrunk.Attempts,
+		Exhausted: shrunk.Exhausted,
+	}, nil
+}
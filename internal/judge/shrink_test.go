@@ -0,0 +1,98 @@
+package judge
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/marv972228/sandbox_judge/internal/problem"
+	"github.com/marv972228/sandbox_judge/internal/runner"
+)
+
+// shrinkPrograms are a reference that echoes its input, a solution that
+// answers "wrong" if the input contains 7, and a validator that requires
+// at least two numbers
+var shrinkPrograms = programs{
+	"validate.py": func(cfg runner.RunConfig) *runner.RunResult {
+		if len(strings.Fields(cfg.Stdin)) < 2 {
+			return exitWith(1, "need two numbers")(cfg)
+		}
+		return exitWith(0, "")(cfg)
+	},
+	"solution.py": func(cfg runner.RunConfig) *runner.RunResult {
+		if slices.Contains(strings.Fields(cfg.Stdin), "7") {
+			return prints("wrong\n")(cfg)
+		}
+		return echoRunner(cfg)
+	},
+}
+
+func TestShrink_MinimizesFailingTest(t *testing.T) {
+	files := echoTests([]string{"sample/1"})
+	files["../validate.py"] = ""
+	loader := writeProblem(t, "p", "id: p\nvalidator: validate.py\n", files)
+	j := &Judge{problemLoader: loader, runner: &fakeRunner{run: shrinkPrograms.run}}
+
+	tc := problem.TestCase{
+		Name:         "hidden/9",
+		Input:        "3 1 4 1 5 9 2 6 5 3 5 8 9 7 9 3\n",
+		Expected:     "3 1 4 1 5 9 2 6 5 3 5 8 9 7 9 3\n",
+		Alternatives: []string{"3 1 4 1 5 9 2 6 5 3 5 8 9 7 9\n"},
+	}
+	result, err := j.Shrink(context.Background(), "p", "solution.py", tc, ShrinkConfig{ReferencePath: "ref.py"})
+	if err != nil {
+		t.Fatalf("Shrink returned error: %v", err)
+	}
+
+	if result.Original.Verdict != runner.VerdictWrongAnswer {
+		t.Errorf("Original verdict = %s, want WA", result.Original.Verdict)
+	}
+	minimal := result.Minimal.TestCase.Input
+	if minimal != "0 7\n" && minimal != "7 0\n" {
+		t.Errorf("Minimal input = %q, want 7 and a zero", minimal)
+	}
+	if result.Minimal.Verdict != runner.VerdictWrongAnswer || result.Minimal.TestCase.Expected != minimal {
+		t.Errorf("Unexpected minimal result: %+v", result.Minimal)
+	}
+	if alts := result.Minimal.TestCase.Alternatives; alts != nil {
+		t.Errorf("Minimal test kept the original's alternatives %q", alts)
+	}
+}
+
+func TestShrink_PassingTest(t *testing.T) {
+	loader := writeProblem(t, "p", "id: p\n", echoTests([]string{"sample/1"}))
+	j := &Judge{problemLoader: loader, runner: &fakeRunner{run: shrinkPrograms.run}}
+
+	tc := problem.TestCase{Name: "sample/1", Input: "1 2\n", Expected: "1 2\n"}
+	if _, err := j.Shrink(context.Background(), "p", "solution.py", tc, ShrinkConfig{ReferencePath: "ref.py"}); err == nil {
+		t.Error("Expected error when the test passes")
+	}
+}
+
+func TestShrink_DefaultsToProblemReference(t *testing.T) {
+	files := echoTests([]string{"sample/1"})
+	files["../ref.py"] = ""
+	loader := writeProblem(t, "p", "id: p\nreference: ref.py\n", files)
+	fr := &fakeRunner{run: shrinkPrograms.run}
+	j := &Judge{problemLoader: loader, runner: fr}
+
+	tc := problem.TestCase{Name: "hidden/1", Input: "1 7\n", Expected: "1 7\n"}
+	if _, err := j.Shrink(context.Background(), "p", "solution.py", tc, ShrinkConfig{MaxAttempts: 1}); err != nil {
+		t.Fatalf("Shrink returned error: %v", err)
+	}
+	ran := false
+	for _, cfg := range fr.configs {
+		ran = ran || cfg.SourcePath == filepath.Join(loader.Dir("p"), "ref.py")
+	}
+	if !ran {
+		t.Error("the problem's reference was not run")
+	}
+
+	noRef := writeProblem(t, "q", "id: q\n", echoTests([]string{"sample/1"}))
+	j = &Judge{problemLoader: noRef, runner: &fakeRunner{run: shrinkPrograms.run}}
+	if _, err := j.Shrink(context.Background(), "q", "solution.py", tc, ShrinkConfig{}); err == nil {
+		t.Error("Expected error without any reference")
+	}
+}
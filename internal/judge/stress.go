@@ -0,0 +1,149 @@
+package judge
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/marv972228/sandbox_judge/internal/problem"
+	"github.com/marv972228/sandbox_judge/internal/runner"
+)
+
+// Limits for helper programs (generators, reference solutions), which are
+// trusted but may be slow, e.g. a brute force on a small input
+const (
+	helperTimeLimit   = 10 * time.Second
+	helperMemoryLimit = 512 * 1024 * 1024
+)
+
+// DefaultStressIterations is the number of inputs tried when none is given
+const DefaultStressIterations = 500
+
+// StressConfig configures a stress test
+type StressConfig struct {
+	// GeneratorPath is a program that prints a random input for the seed in its first argument
+	GeneratorPath string
+
+	// ReferencePath is a trusted (usually brute-force) solution
+	ReferencePath string
+
+	// Iterations is the maximum number of inputs to try (0 = DefaultStressIterations)
+	Iterations int
+
+	// Seed is the seed of the first input; later inputs use Seed+1, Seed+2, ...
+	Seed int64
+}
+
+// StressResult holds the outcome of a stress test
+type StressResult struct {
+	// Iterations is the number of inputs tried
+	Iterations int
+
+	// Counterexample is the first failing test, or nil if none was found.
+	// Its Expected output comes from the reference solution.
+	Counterexample *TestResult
+
+	// Seed is the generator seed that produced the counterexample
+	Seed int64
+}
+
+// Stress runs a solution and a reference on generated inputs until their
+// outputs disagree under the problem's comparator, or the solution fails
+// otherwise (TLE, RE, ...). If ctx is cancelled, it returns the inputs
+// tried so far along with ctx's error.
+func (j *Judge) Stress(ctx context.Context, problemID, solutionPath string, cfg StressConfig) (*StressResult, error) {
+	sub, err := j.prepareSolution(problemID, solutionPath)
+	if err != nil {
+		return nil, err
+	}
+
+	iterations := cfg.Iterations
+	if iterations <= 0 {
+		iterations = DefaultStressIterations
+	}
+
+	result := &StressResult{}
+	for i := 0; i < iterations; i++ {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		seed := cfg.Seed + int64(i)
+		input, err := j.runHelper(ctx, cfg.GeneratorPath, "", strconv.FormatInt(seed, 10))
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("generator failed for seed %d: %w", seed, err)
+		}
+
+		expected, err := j.runReference(ctx, sub.problem, cfg.ReferencePath, input)
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reference failed for seed %d: %w", seed, err)
+		}
+
+		tc := problem.TestCase{
+			Name:     fmt.Sprintf("stress/seed-%d", seed),
+			Input:    input,
+			Expected: expected,
+		}
+		tr := j.runTestCase(ctx, sub, tc)
+		if ctx.Err() != nil {
+			// The run was killed, so its verdict says nothing about the solution
+			return result, ctx.Err()
+		}
+		result.Iterations++
+
+		if tr.Verdict == runner.VerdictSystemError {
+			return nil, fmt.Errorf("seed %d: %s", seed, tr.Error)
+		}
+		if isFailure(tr.Verdict) {
+			result.Counterexample = &tr
+			result.Seed = seed
+			return result, nil
+		}
+	}
+
+	return result, nil
+}
+
+// runHelper runs a trusted helper program and returns its stdout.
+// Any verdict other than AC is an error.
+func (j *Judge) runHelper(ctx context.Context, path, stdin string, args ...string) (string, error) {
+	ext := filepath.Ext(path)
+	language := extensionToLanguage(ext)
+	if language == "" {
+		return "", fmt.Errorf("unsupported file extension: %s", ext)
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", path, err)
+	}
+
+	res, err := j.runner.Run(ctx, runner.RunConfig{
+		Language:    language,
+		SourcePath:  absPath,
+		Stdin:       stdin,
+		TimeLimit:   helperTimeLimit,
+		MemoryLimit: helperMemoryLimit,
+		Args:        args,
+	})
+	if err != nil {
+		return "", err
+	}
+	if res.Verdict != runner.VerdictAccepted {
+		msg := strings.TrimSpace(res.Stderr)
+		if msg == "" && res.Error != nil {
+			msg = res.Error.Error()
+		}
+		return "", fmt.Errorf("%s: %s", res.Verdict, msg)
+	}
+	return res.Stdout, nil
+}
@@ -0,0 +1,87 @@
+package judge
+
+import (
+	"context"
+	"testing"
+
+	"github.com/marv972228/sandbox_judge/internal/runner"
+)
+
+// stressPrograms are a generator that prints its seed, a reference that
+// echoes its input, and a solution that is wrong for the input "7"
+var stressPrograms = programs{
+	"gen.py":      printsArg(0),
+	"solution.py": byInput(map[string]play{"7\n": prints("8\n")}),
+}
+
+func TestStress_FindsCounterexample(t *testing.T) {
+	loader := writeProblem(t, "p", "id: p\n", echoTests([]string{"sample/1"}))
+	j := &Judge{problemLoader: loader, runner: &fakeRunner{run: stressPrograms.run}}
+
+	result, err := j.Stress(context.Background(), "p", "solution.py", StressConfig{
+		GeneratorPath: "gen.py",
+		ReferencePath: "ref.py",
+		Seed:          3,
+	})
+	if err != nil {
+		t.Fatalf("Stress returned error: %v", err)
+	}
+
+	ce := result.Counterexample
+	if ce == nil {
+		t.Fatal("Expected a counterexample")
+	}
+	if result.Seed != 7 || result.Iterations != 5 {
+		t.Errorf("Seed/Iterations = %d/%d, want 7/5", result.Seed, result.Iterations)
+	}
+	if ce.Verdict != runner.VerdictWrongAnswer || ce.TestCase.Input != "7\n" || ce.TestCase.Expected != "7\n" {
+		t.Errorf("Unexpected counterexample: %+v", ce)
+	}
+}
+
+func TestStress_NoCounterexample(t *testing.T) {
+	loader := writeProblem(t, "p", "id: p\n", echoTests([]string{"sample/1"}))
+	j := &Judge{problemLoader: loader, runner: &fakeRunner{run: stressPrograms.run}}
+
+	result, err := j.Stress(context.Background(), "p", "solution.py", StressConfig{
+		GeneratorPath: "gen.py",
+		ReferencePath: "ref.py",
+		Seed:          10,
+		Iterations:    4,
+	})
+	if err != nil {
+		t.Fatalf("Stress returned error: %v", err)
+	}
+	if result.Counterexample != nil || result.Iterations != 4 {
+		t.Errorf("Expected 4 passing iterations, got %+v", result)
+	}
+}
+
+func TestStress_GeneratorFailure(t *testing.T) {
+	loader := writeProblem(t, "p", "id: p\n", echoTests([]string{"sample/1"}))
+	j := &Judge{problemLoader: loader, runner: &fakeRunner{run: programs{"gen.py": exitWith(1, "boom")}.run}}
+
+	if _, err := j.Stress(context.Background(), "p", "solution.py", StressConfig{GeneratorPath: "gen.py", ReferencePath: "ref.py"}); err == nil {
+		t.Error("Expected error when the generator fails")
+	}
+}
+
+func TestStress_Interrupted(t *testing.T) {
+	loader := writeProblem(t, "p", "id: p\n", echoTests([]string{"sample/1"}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	killed := func(runner.RunConfig) *runner.RunResult {
+		cancel() // Ctrl-C kills the solution mid-run
+		return &runner.RunResult{Verdict: runner.VerdictRuntimeError, ExitCode: 137}
+	}
+	fake := programs{"gen.py": printsArg(0), "solution.py": byInput(map[string]play{"3\n": killed})}
+	j := &Judge{problemLoader: loader, runner: &fakeRunner{run: fake.run}}
+
+	result, err := j.Stress(ctx, "p", "solution.py", StressConfig{GeneratorPath: "gen.py", ReferencePath: "ref.py", Seed: 1})
+	if err != context.Canceled || result == nil {
+		t.Fatalf("Stress = %v, %v; want partial result and context.Canceled", result, err)
+	}
+	if result.Counterexample != nil || result.Iterations != 2 {
+		t.Errorf("Expected 2 finished iterations and no counterexample, got %+v", result)
+	}
+}
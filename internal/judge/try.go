@@ -0,0 +1,109 @@
+package judge
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/marv972228/sandbox_judge/internal/problem"
+	"github.com/marv972228/sandbox_judge/internal/runner"
+)
+
+// TryResult holds the outcome of running a solution on custom input
+type TryResult struct {
+	// Language is the detected language of the solution
+	Language string
+
+	// TimeLimit is the solution's effective time limit
+	TimeLimit time.Duration
+
+	// Run is the solution's raw run: stdout, stderr, time, memory, and a
+	// verdict of AC if it exited normally within the limits
+	Run *runner.RunResult
+
+	// InvalidInput is the validator's reason for rejecting the input (empty
+	// if the input is valid or the problem has no validator)
+	InvalidInput string
+
+	// Reference is the problem's reference solution (empty if it has none)
+	Reference string
+
+	// Expected is the reference's output
+	Expected string
+
+	// Verdict compares the solution with the reference: AC, WA or PE, or
+	// the solution's own verdict if it did not exit normally (empty without
+	// a reference)
+	Verdict runner.Verdict
+
+	// Message explains why the output was rejected
+	Message string
+}
+
+// Try runs a solution on custom input with the problem's limits and, if the
+// problem has a reference solution, judges the output against the
+// reference's
+func (j *Judge) Try(ctx context.Context, problemID, solutionPath, input string) (*TryResult, error) {
+	sub, err := j.prepareSolution(problemID, solutionPath)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TryResult{Language: sub.language, TimeLimit: sub.timeLimit}
+
+	valid, message, err := j.validateInput(ctx, sub.problem, input)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		result.InvalidInput = message
+	}
+
+	result.Run, err = j.runner.Run(ctx, runner.RunConfig{
+		Language:     sub.language,
+		SourcePath:   sub.sourcePath,
+		Stdin:        input,
+		TimeLimit:    sub.timeLimit,
+		MemoryLimit:  int64(sub.problem.MemoryLimitMB) * 1024 * 1024,
+		SampleMemory: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.Run.Verdict == runner.VerdictSystemError {
+		return nil, fmt.Errorf("solution: %v", result.Run.Error)
+	}
+
+	if sub.problem.Reference == "" {
+		return result, nil
+	}
+	result.Reference = sub.problem.Reference
+
+	referencePath := filepath.Join(j.problemLoader.Dir(problemID), sub.problem.Reference)
+	result.Expected, err = j.runReference(ctx, sub.problem, referencePath, input)
+	if err != nil {
+		return nil, fmt.Errorf("reference solution failed: %w", err)
+	}
+
+	if result.Run.Verdict != runner.VerdictAccepted {
+		result.Verdict = result.Run.Verdict
+		return result, nil
+	}
+
+	tc := problem.TestCase{Name: "custom", Input: input, Expected: result.Expected}
+	comparison, err := j.compareOutput(ctx, sub.comparator, tc, result.Run.Stdout)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case comparison.PresentationError:
+		result.Verdict = runner.VerdictPresentationError
+	case !comparison.Match:
+		result.Verdict = runner.VerdictWrongAnswer
+	default:
+		result.Verdict = runner.VerdictAccepted
+	}
+	result.Message = comparison.Message
+	return result, nil
+}
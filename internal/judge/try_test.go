@@ -0,0 +1,58 @@
+package judge
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/marv972228/sandbox_judge/internal/runner"
+)
+
+func TestTry(t *testing.T) {
+	files := echoTests([]string{"sample/1"})
+	files["../ref.py"] = ""
+	loader := writeProblem(t, "p", "id: p\nreference: ref.py\n", files)
+	noisy := func(cfg runner.RunConfig) *runner.RunResult {
+		return &runner.RunResult{Verdict: runner.VerdictAccepted, Stdout: cfg.Stdin, Stderr: "debug\n"}
+	}
+	fake := programs{"noisy.py": noisy, "wrong.py": prints("wrong\n")}
+	j := &Judge{problemLoader: loader, runner: &fakeRunner{run: fake.run}}
+
+	result, err := j.Try(context.Background(), "p", "noisy.py", "1 2\n")
+	if err != nil {
+		t.Fatalf("Try returned error: %v", err)
+	}
+	if result.Run.Stdout != "1 2\n" || result.Run.Stderr != "debug\n" {
+		t.Errorf("Unexpected raw output: %+v", result.Run)
+	}
+	if result.Reference != "ref.py" || result.Expected != "1 2\n" || result.Verdict != runner.VerdictAccepted {
+		t.Errorf("Unexpected comparison: %+v", result)
+	}
+
+	result, err = j.Try(context.Background(), "p", "wrong.py", "1 2\n")
+	if err != nil {
+		t.Fatalf("Try returned error: %v", err)
+	}
+	if result.Verdict != runner.VerdictWrongAnswer {
+		t.Errorf("Verdict = %s, want WA", result.Verdict)
+	}
+}
+
+func TestTry_WithoutReference(t *testing.T) {
+	files := echoTests([]string{"sample/1"})
+	files["../validate.py"] = ""
+	loader := writeProblem(t, "p", "id: p\nvalidator: validate.py\n", files)
+	fake := programs{"validate.py": exitWith(1, "need two numbers")}
+	j := &Judge{problemLoader: loader, runner: &fakeRunner{run: fake.run}}
+
+	result, err := j.Try(context.Background(), "p", "solution.py", "5\n")
+	if err != nil {
+		t.Fatalf("Try returned error: %v", err)
+	}
+	if result.Run.Stdout != "5\n" || result.Verdict != "" || result.Reference != "" {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+	if !strings.Contains(result.InvalidInput, "need two numbers") {
+		t.Errorf("InvalidInput = %q, want the validator's message", result.InvalidInput)
+	}
+}
@@ -0,0 +1,112 @@
+package judge
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/marv972228/sandbox_judge/internal/problem"
+	"github.com/marv972228/sandbox_judge/internal/runner"
+)
+
+// InputCheck is the validator's verdict on one test input
+type InputCheck struct {
+	// TestCase is the test whose input was checked
+	TestCase problem.TestCase
+
+	// Valid is true if the validator accepted the input
+	Valid bool
+
+	// Message is the validator's explanation for rejecting the input
+	Message string
+}
+
+// ValidationResult holds the validator's verdicts on a problem's tests
+type ValidationResult struct {
+	// ProblemID is the problem whose tests were checked
+	ProblemID string
+
+	// Inputs holds one check per test case, in test order
+	Inputs []InputCheck
+}
+
+// Invalid returns the checks of rejected inputs
+func (r *ValidationResult) Invalid() []InputCheck {
+	var invalid []InputCheck
+	for _, ic := range r.Inputs {
+		if !ic.Valid {
+			invalid = append(invalid, ic)
+		}
+	}
+	return invalid
+}
+
+// ValidateTests runs the problem's validator on every test input
+func (j *Judge) ValidateTests(ctx context.Context, problemID string) (*ValidationResult, error) {
+	prob, err := j.problemLoader.Load(problemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load problem %s: %w", problemID, err)
+	}
+	if prob.Validator == "" {
+		return nil, fmt.Errorf("problem %s has no validator (add `validator:` to problem.yaml)", problemID)
+	}
+
+	testCases, err := j.problemLoader.LoadTestCases(ctx, problemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load test cases: %w", err)
+	}
+
+	result := &ValidationResult{ProblemID: problemID}
+	for _, tc := range testCases {
+		valid, message, err := j.validateInput(ctx, prob, tc.Input)
+		if err != nil {
+			return nil, fmt.Errorf("test %s: %w", tc.Name, err)
+		}
+		result.Inputs = append(result.Inputs, InputCheck{TestCase: tc, Valid: valid, Message: message})
+	}
+
+	return result, nil
+}
+
+// validateInput runs the problem's validator on an input. It returns false
+// with the validator's message if the input breaks the constraints, and
+// true if it is valid or the problem has no validator.
+func (j *Judge) validateInput(ctx context.Context, prob *problem.Problem, input string) (bool, string, error) {
+	if prob.Validator == "" {
+		return true, "", nil
+	}
+
+	validatorPath := filepath.Join(j.problemLoader.Dir(prob.ID), prob.Validator)
+	ext := filepath.Ext(validatorPath)
+	language := extensionToLanguage(ext)
+	if language == "" {
+		return false, "", fmt.Errorf("unsupported validator file extension: %s", ext)
+	}
+
+	res, err := j.runner.Run(ctx, runner.RunConfig{
+		Language:    language,
+		SourcePath:  validatorPath,
+		Stdin:       input,
+		TimeLimit:   helperTimeLimit,
+		MemoryLimit: helperMemoryLimit,
+	})
+	if err != nil {
+		return false, "", fmt.Errorf("validator failed: %w", err)
+	}
+
+	message := strings.TrimSpace(res.Stderr)
+	if message == "" {
+		message = strings.TrimSpace(res.Stdout)
+	}
+
+	switch res.Verdict {
+	case runner.VerdictAccepted:
+		return true, "", nil
+	case runner.VerdictRuntimeError:
+		// A non-zero exit code rejects the input
+		return false, message, nil
+	default:
+		return false, "", fmt.Errorf("validator failed with %s: %s", res.Verdict, message)
+	}
+}
@@ -0,0 +1,73 @@
+package judge
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// validatorPrograms are a validator that rejects the input of hidden/2
+var validatorPrograms = programs{
+	"validate.py": byInput(map[string]play{"hidden/2\n": exitWith(1, "n out of range\n")}),
+}
+
+func TestValidateTests(t *testing.T) {
+	files := echoTests([]string{"sample/1", "hidden/1", "hidden/2"})
+	files["../validate.py"] = ""
+	loader := writeProblem(t, "p", "id: p\nvalidator: validate.py\n", files)
+	j := &Judge{problemLoader: loader, runner: &fakeRunner{run: validatorPrograms.run}}
+
+	result, err := j.ValidateTests(context.Background(), "p")
+	if err != nil {
+		t.Fatalf("ValidateTests returned error: %v", err)
+	}
+
+	if len(result.Inputs) != 3 {
+		t.Fatalf("Checked %d inputs, want 3", len(result.Inputs))
+	}
+	invalid := result.Invalid()
+	if len(invalid) != 1 || invalid[0].TestCase.Name != "hidden/2" {
+		t.Fatalf("Invalid inputs = %+v, want only hidden/2", invalid)
+	}
+	if invalid[0].Message != "n out of range" {
+		t.Errorf("Message = %q, want %q", invalid[0].Message, "n out of range")
+	}
+}
+
+func TestValidateTests_NoValidator(t *testing.T) {
+	loader := writeProblem(t, "p", "id: p\n", echoTests([]string{"sample/1"}))
+	j := &Judge{problemLoader: loader, runner: &fakeRunner{run: validatorPrograms.run}}
+
+	_, err := j.ValidateTests(context.Background(), "p")
+	if err == nil || !strings.Contains(err.Error(), "no validator") {
+		t.Errorf("Expected a missing validator error, got %v", err)
+	}
+}
+
+func TestVerify_ValidatesInputs(t *testing.T) {
+	files := echoTests([]string{"sample/1", "hidden/1", "hidden/2"})
+	files["../validate.py"] = ""
+	files["../correct.py"] = ""
+	yaml := `id: p
+validator: validate.py
+solutions:
+  - {path: correct.py, expected: AC}
+`
+	loader := writeProblem(t, "p", yaml, files)
+	j := &Judge{problemLoader: loader, runner: &fakeRunner{run: validatorPrograms.run}}
+
+	result, err := j.Verify(context.Background(), "p")
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+
+	if result.Validation == nil || len(result.Validation.Invalid()) != 1 {
+		t.Fatalf("Validation = %+v, want one invalid input", result.Validation)
+	}
+	if !result.Solutions[0].OK {
+		t.Errorf("correct.py should still pass: %s", result.Solutions[0].Reason)
+	}
+	if result.OK() {
+		t.Error("Expected verification to fail on an invalid input")
+	}
+}
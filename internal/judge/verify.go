@@ -0,0 +1,115 @@
+package judge
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/marv972228/sandbox_judge/internal/problem"
+	"github.com/marv972228/sandbox_judge/internal/runner"
+)
+
+// SolutionCheck is the outcome of running one reference solution
+type SolutionCheck struct {
+	// Solution is the entry from problem.yaml
+	Solution problem.Solution
+
+	// Result of running the solution (nil if it could not be run)
+	Result *Result
+
+	// OK is true if the solution got its expected verdict
+	OK bool
+
+	// Reason explains a mismatch or error (empty if OK)
+	Reason string
+}
+
+// VerifyResult holds the outcome of verifying a problem
+type VerifyResult struct {
+	// ProblemID is the problem that was verified
+	ProblemID string
+
+	// Validation holds the validator's verdicts on the test inputs (nil
+	// if the problem has no validator)
+	Validation *ValidationResult
+
+	// Solutions holds one check per solution listed in problem.yaml
+	Solutions []SolutionCheck
+}
+
+// OK reports whether every test input is valid and every solution got its
+// expected verdict
+func (r *VerifyResult) OK() bool {
+	if r.Validation != nil && len(r.Validation.Invalid()) > 0 {
+		return false
+	}
+	for _, sc := range r.Solutions {
+		if !sc.OK {
+			return false
+		}
+	}
+	return true
+}
+
+// Verify checks the test inputs with the problem's validator, if any, then
+// runs every solution listed in problem.yaml on all tests and checks that
+// each gets its expected verdict. A solution that cannot be run counts
+// as a failed check rather than an error, so one broken entry does not hide
+// the others.
+func (j *Judge) Verify(ctx context.Context, problemID string) (*VerifyResult, error) {
+	prob, err := j.problemLoader.Load(problemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load problem %s: %w", problemID, err)
+	}
+	if len(prob.Solutions) == 0 {
+		return nil, fmt.Errorf("problem %s lists no solutions (add `solutions:` to problem.yaml)", problemID)
+	}
+
+	result := &VerifyResult{ProblemID: problemID}
+	if prob.Validator != "" {
+		result.Validation, err = j.ValidateTests(ctx, problemID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, sol := range prob.Solutions {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		check := SolutionCheck{Solution: sol}
+		path := filepath.Join(j.problemLoader.Dir(problemID), sol.Path)
+
+		// Every test must run to tell "TLE somewhere" from "TLE and WA"
+		check.Result, err = j.run(ctx, problemID, path, Selection{}, PolicyAll)
+		if err != nil {
+			check.Reason = err.Error()
+		} else {
+			check.OK, check.Reason = checkExpected(runner.Verdict(sol.Expected), check.Result)
+		}
+		result.Solutions = append(result.Solutions, check)
+	}
+
+	return result, nil
+}
+
+// checkExpected reports whether a result matches an expected verdict: AC
+// means every test passed; any other verdict must occur on at least one test,
+// and no test may fail in a different way
+func checkExpected(expected runner.Verdict, r *Result) (bool, string) {
+	found := false
+	for _, tr := range r.TestResults {
+		switch {
+		case tr.Verdict == expected:
+			found = true
+		case isFailure(tr.Verdict):
+			return false, fmt.Sprintf("expected %s, but %s got %s", expected, tr.TestCase.Name, tr.Verdict)
+		}
+	}
+
+	if expected != runner.VerdictAccepted && !found {
+		return false, fmt.Sprintf("expected %s on some test, but every test passed", expected)
+	}
+	return true, ""
+}
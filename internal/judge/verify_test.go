@@ -0,0 +1,56 @@
+package judge
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// verifyPrograms are solutions named after their behavior: correct.py
+// echoes, wrong.py prints garbage, slow.py times out on hidden tests and
+// mixed.py times out on hidden/1 and answers wrong on hidden/2
+var verifyPrograms = programs{
+	"wrong.py": prints("garbage\n"),
+	"slow.py":  byInput(map[string]play{"hidden/1\n": timesOut, "hidden/2\n": timesOut}),
+	"mixed.py": byInput(map[string]play{"hidden/1\n": timesOut, "hidden/2\n": prints("garbage\n")}),
+}
+
+func TestVerify(t *testing.T) {
+	files := echoTests([]string{"sample/1", "hidden/1", "hidden/2"})
+	for _, name := range []string{"correct.py", "wrong.py", "slow.py", "mixed.py"} {
+		files["../"+name] = ""
+	}
+	yaml := `id: p
+solutions:
+  - {path: correct.py, expected: AC}
+  - {path: wrong.py, expected: WA}
+  - {path: slow.py, expected: TLE}
+  - {path: mixed.py, expected: TLE}
+  - {path: correct.py, expected: WA}
+`
+	loader := writeProblem(t, "p", yaml, files)
+	j := &Judge{problemLoader: loader, runner: &fakeRunner{run: verifyPrograms.run}, policy: PolicyFailFast}
+
+	result, err := j.Verify(context.Background(), "p")
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+
+	wantOK := []bool{true, true, true, false, false}
+	for i, sc := range result.Solutions {
+		if sc.OK != wantOK[i] {
+			t.Errorf("%s (expected %s): OK = %v, want %v (%s)", sc.Solution.Path, sc.Solution.Expected, sc.OK, wantOK[i], sc.Reason)
+		}
+	}
+	if !strings.Contains(result.Solutions[3].Reason, "hidden/2 got WA") {
+		t.Errorf("mixed.py reason = %q, want it to name hidden/2", result.Solutions[3].Reason)
+	}
+	if result.OK() {
+		t.Error("Expected verification to fail")
+	}
+
+	// Verification ignores the judge's fail-fast policy
+	if got := result.Solutions[2].Result.Skipped; got != 0 {
+		t.Errorf("slow.py skipped %d tests, want 0", got)
+	}
+}
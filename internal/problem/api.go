@@ -0,0 +1,44 @@
+package problem
+
+import "github.com/marv972228/sandbox_judge/pkg/api"
+
+// API converts the problem to its public wire format.
+func (p *Problem) API() *api.Problem {
+	out := &api.Problem{
+		SchemaVersion: api.SchemaVersion,
+		ID:            p.ID,
+		Title:         p.Title,
+		Difficulty:    string(p.Difficulty),
+		Tags:          p.Tags,
+		Description:   p.Description,
+		InputFormat:   p.InputFormat,
+		OutputFormat:  p.OutputFormat,
+		Constraints:   p.Constraints,
+		TimeLimitMS:   p.TimeLimitMS,
+		MemoryLimitMB: p.MemoryLimitMB,
+	}
+	if out.Tags == nil {
+		out.Tags = []string{}
+	}
+
+	for _, ex := range p.Examples {
+		out.Examples = append(out.Examples, api.Example{Input: ex.Input, Output: ex.Output, Explanation: ex.Explanation})
+	}
+	if len(p.TimeLimitOverrides) > 0 {
+		out.TimeLimitOverrides = make(map[string]string, len(p.TimeLimitOverrides))
+		for lang, o := range p.TimeLimitOverrides {
+			out.TimeLimitOverrides[lang] = o.String()
+		}
+	}
+	for _, st := range p.Subtasks {
+		out.Subtasks = append(out.Subtasks, api.Subtask{Name: st.Name, Points: st.Points})
+	}
+	if p.Signature != nil {
+		sig := &api.Signature{Function: p.Signature.Function, Params: []api.Param{}, Returns: string(p.Signature.Returns)}
+		for _, param := range p.Signature.Params {
+			sig.Params = append(sig.Params, api.Param{Name: param.Name, Type: string(param.Type)})
+		}
+		out.Signature = sig
+	}
+	return out
+}
@@ -0,0 +1,48 @@
+package problem
+
+import (
+	"testing"
+
+	"github.com/marv972228/sandbox_judge/pkg/api"
+)
+
+func TestProblemAPI(t *testing.T) {
+	p := &Problem{
+		ID:                 "p",
+		Title:              "P",
+		Difficulty:         DifficultyEasy,
+		TimeLimitMS:        1000,
+		MemoryLimitMB:      256,
+		TimeLimitOverrides: map[string]TimeLimitOverride{"python": {Multiplier: 3}},
+		Examples:           []Example{{Input: "1\n", Output: "1\n"}},
+		Subtasks:           []Subtask{{Name: "small", Points: 40}},
+	}
+
+	got := p.API()
+
+	if got.SchemaVersion != api.SchemaVersion || got.ID != "p" || got.Difficulty != "easy" {
+		t.Errorf("Unexpected problem: %+v", got)
+	}
+	if got.Tags == nil {
+		t.Error("Tags should be an empty list, not null")
+	}
+	if got.TimeLimitOverrides["python"] != "3x" {
+		t.Errorf("python override = %q, want 3x", got.TimeLimitOverrides["python"])
+	}
+	if len(got.Examples) != 1 || len(got.Subtasks) != 1 || got.Subtasks[0].Points != 40 {
+		t.Errorf("Unexpected examples or subtasks: %+v", got)
+	}
+}
+
+func TestProblemAPI_Signature(t *testing.T) {
+	p := &Problem{ID: "p"}
+	if p.API().Signature != nil {
+		t.Error("stdin problem should have no signature")
+	}
+
+	p.Signature = &Signature{Function: "f", Params: []Param{{Name: "a", Type: "int[]"}}, Returns: TypeTree}
+	got := p.API().Signature
+	if got == nil || got.Function != "f" || len(got.Params) != 1 || got.Params[0].Type != "int[]" || got.Returns != "tree" {
+		t.Errorf("Unexpected signature: %+v", got)
+	}
+}
@@ -0,0 +1,183 @@
+package problem
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DefaultGeneratedGroup is the test group of generated tests without one
+const DefaultGeneratedGroup = "generated"
+
+// GeneratedTests declares hidden tests built by running a generator once
+// per seed and the problem's reference solution on each input.
+type GeneratedTests struct {
+	// Generator is a program, relative to the problem dir, called as
+	// `generator <seed> <args...>` that prints one input
+	Generator string `yaml:"generator"`
+
+	// Args are passed to the generator after the seed
+	Args []string `yaml:"args,omitempty"`
+
+	// Seeds to run the generator with, one test per seed
+	Seeds []int64 `yaml:"seeds"`
+
+	// Group names the tests, e.g. "generated/3" (default DefaultGeneratedGroup)
+	Group string `yaml:"group,omitempty"`
+}
+
+// TestBuilder produces the input and expected output of one generated test,
+// normally by running the generator and the reference in the sandbox.
+type TestBuilder func(ctx context.Context, p *Problem, g GeneratedTests, seed int64) (input, expected string, err error)
+
+// cacheDir holds built tests under the problem directory
+const cacheDir = ".cache/generated"
+
+// validateGenerated checks the generated test entries and the reference.
+func (p *Problem) validateGenerated() error {
+	if len(p.Generated) > 0 && p.Reference == "" {
+		return fmt.Errorf("generated tests require a reference solution")
+	}
+	for i, g := range p.Generated {
+		if g.Generator == "" {
+			return fmt.Errorf("generated %d: generator is required", i+1)
+		}
+		if len(g.Seeds) == 0 {
+			return fmt.Errorf("generated %d: no seeds listed", i+1)
+		}
+		seen := make(map[int64]bool)
+		for _, seed := range g.Seeds {
+			if seen[seed] {
+				return fmt.Errorf("generated %d: duplicate seed %d", i+1, seed)
+			}
+			seen[seed] = true
+		}
+		if g.Group == "sample" || strings.ContainsAny(g.Group, `/\`) {
+			return fmt.Errorf("generated %d: invalid group %q", i+1, g.Group)
+		}
+	}
+	return nil
+}
+
+// loadGeneratedTests returns the problem's generated tests, building the
+// ones missing from the cache. Tests are numbered per group in declaration
+// order.
+func (l *Loader) loadGeneratedTests(ctx context.Context, id string, p *Problem) ([]TestCase, error) {
+	problemDir := l.Dir(id)
+	numbers := make(map[string]int)
+	keys := make(map[string]bool)
+
+	var testCases []TestCase
+	for _, g := range p.Generated {
+		key, err := generatedKey(problemDir, p.Reference, g)
+		if err != nil {
+			return nil, err
+		}
+		keys[key] = true
+		dir := filepath.Join(problemDir, cacheDir, key)
+
+		for _, seed := range g.Seeds {
+			input, expected, err := l.generatedTest(ctx, p, g, seed, dir)
+			if err != nil {
+				return nil, fmt.Errorf("generated test %s (seed %d): %w", g.Generator, seed, err)
+			}
+			numbers[g.Group]++
+			testCases = append(testCases, TestCase{
+				Name:     fmt.Sprintf("%s/%d", g.Group, numbers[g.Group]),
+				Input:    input,
+				Expected: expected,
+			})
+		}
+	}
+
+	pruneCache(filepath.Join(problemDir, cacheDir), keys)
+	return testCases, nil
+}
+
+// generatedTest reads one generated test from the cache, building and
+// storing it first if needed
+func (l *Loader) generatedTest(ctx context.Context, p *Problem, g GeneratedTests, seed int64, dir string) (string, string, error) {
+	base := filepath.Join(dir, strconv.FormatInt(seed, 10))
+	input, errIn := os.ReadFile(base + ".in")
+	expected, errOut := os.ReadFile(base + ".out")
+	if errIn == nil && errOut == nil {
+		return string(input), string(expected), nil
+	}
+
+	if l.builder == nil {
+		return "", "", fmt.Errorf("not built yet (run the problem through the judge to build it)")
+	}
+	in, out, err := l.builder(ctx, p, g, seed)
+	if err != nil {
+		return "", "", err
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", "", fmt.Errorf("failed to create cache: %w", err)
+	}
+	// Both files are written atomically, so a half-built test is rebuilt
+	for _, f := range []struct{ ext, content string }{{".out", out}, {".in", in}} {
+		if err := writeFileAtomic(base+f.ext, f.content); err != nil {
+			return "", "", err
+		}
+	}
+	return in, out, nil
+}
+
+// generatedKey hashes everything a generated test depends on except its
+// seed: the generator and reference (paths and contents) and the arguments
+func generatedKey(problemDir, reference string, g GeneratedTests) (string, error) {
+	h := sha256.New()
+	for _, name := range []string{g.Generator, reference} {
+		data, err := os.ReadFile(filepath.Join(problemDir, name))
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00", name, len(data))
+		h.Write(data)
+	}
+	for _, arg := range g.Args {
+		fmt.Fprintf(h, "%s\x00", arg)
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16], nil
+}
+
+// pruneCache removes cached builds whose generator, reference or arguments
+// have since changed
+func pruneCache(dir string, keep map[string]bool) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if entry.IsDir() && !keep[entry.Name()] {
+			os.RemoveAll(filepath.Join(dir, entry.Name()))
+		}
+	}
+}
+
+// writeFileAtomic writes a file via a temporary file and rename, so an
+// interrupted build never leaves a truncated file behind
+func writeFileAtomic(path, content string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	_, err = tmp.WriteString(content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
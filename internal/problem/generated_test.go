@@ -0,0 +1,164 @@
+package problem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeGeneratedProblem creates a problem with one sample test and two
+// generated entries, one of them in a named group
+func writeGeneratedProblem(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"problem.yaml": `id: p
+reference: ref.py
+generated:
+  - generator: gen.py
+    args: [100, worst]
+    seeds: [1, 2]
+  - generator: gen.py
+    seeds: [7]
+    group: big
+`,
+		"gen.py":             "# generator\n",
+		"ref.py":             "# reference v1\n",
+		"tests/sample/1.in":  "1\n",
+		"tests/sample/1.out": "1\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, "p", name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// countingBuilder builds "<seed> <args>" inputs and counts its calls
+func countingBuilder(calls *int) TestBuilder {
+	return func(ctx context.Context, p *Problem, g GeneratedTests, seed int64) (string, string, error) {
+		*calls++
+		input := strings.TrimSpace(fmt.Sprintf("%d %s", seed, strings.Join(g.Args, " "))) + "\n"
+		return input, "answer " + input, nil
+	}
+}
+
+func TestLoadTestCases_Generated(t *testing.T) {
+	dir := writeGeneratedProblem(t)
+	l := NewLoader(dir)
+	calls := 0
+	l.SetTestBuilder(countingBuilder(&calls))
+
+	testCases, err := l.LoadTestCases(context.Background(), "p")
+	if err != nil {
+		t.Fatalf("LoadTestCases returned error: %v", err)
+	}
+
+	want := map[string]string{
+		"generated/1": "1 100 worst\n",
+		"generated/2": "2 100 worst\n",
+		"big/1":       "7\n",
+	}
+	if len(testCases) != 4 {
+		t.Fatalf("Loaded %d tests, want 4", len(testCases))
+	}
+	for _, tc := range testCases[1:] {
+		if want[tc.Name] != tc.Input || tc.Expected != "answer "+tc.Input || tc.Sample {
+			t.Errorf("Unexpected generated test %+v", tc)
+		}
+	}
+	if calls != 3 {
+		t.Errorf("Builder called %d times, want 3", calls)
+	}
+
+	// A second load is served from the cache, even without a builder
+	cached, err := NewLoader(dir).LoadTestCases(context.Background(), "p")
+	if err != nil {
+		t.Fatalf("Cached LoadTestCases returned error: %v", err)
+	}
+	if len(cached) != 4 || cached[3].Input != "7\n" {
+		t.Errorf("Unexpected cached tests: %+v", cached)
+	}
+}
+
+func TestLoadTestCases_GeneratedCacheInvalidation(t *testing.T) {
+	dir := writeGeneratedProblem(t)
+	l := NewLoader(dir)
+	calls := 0
+	l.SetTestBuilder(countingBuilder(&calls))
+
+	if _, err := l.LoadTestCases(context.Background(), "p"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Changing the reference rebuilds every test and drops the stale builds
+	if err := os.WriteFile(filepath.Join(dir, "p", "ref.py"), []byte("# reference v2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	calls = 0
+	if _, err := l.LoadTestCases(context.Background(), "p"); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 3 {
+		t.Errorf("Builder called %d times after changing the reference, want 3", calls)
+	}
+	entries, err := os.ReadDir(filepath.Join(dir, "p", cacheDir))
+	if err != nil || len(entries) != 2 {
+		t.Errorf("Cache holds %d builds (%v), want 2", len(entries), err)
+	}
+}
+
+func TestLoadTestCases_GeneratedWithoutBuilder(t *testing.T) {
+	dir := writeGeneratedProblem(t)
+
+	_, err := NewLoader(dir).LoadTestCases(context.Background(), "p")
+	if err == nil || !strings.Contains(err.Error(), "not built") {
+		t.Errorf("Expected a not built error, got %v", err)
+	}
+}
+
+func TestLoadTestCases_GeneratedUsesContext(t *testing.T) {
+	dir := writeGeneratedProblem(t)
+	l := NewLoader(dir)
+	l.SetTestBuilder(func(ctx context.Context, p *Problem, g GeneratedTests, seed int64) (string, string, error) {
+		return "", "", ctx.Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := l.LoadTestCases(ctx, "p"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the caller's cancellation, got %v", err)
+	}
+}
+
+func TestValidateGenerated(t *testing.T) {
+	tests := []struct {
+		name string
+		p    Problem
+		want string
+	}{
+		{"no reference", Problem{Generated: []GeneratedTests{{Generator: "gen.py", Seeds: []int64{1}}}}, "reference"},
+		{"no seeds", Problem{Reference: "ref.py", Generated: []GeneratedTests{{Generator: "gen.py"}}}, "no seeds"},
+		{"duplicate seed", Problem{Reference: "ref.py", Generated: []GeneratedTests{{Generator: "gen.py", Seeds: []int64{1, 1}}}}, "duplicate seed"},
+		{"sample group", Problem{Reference: "ref.py", Generated: []GeneratedTests{{Generator: "gen.py", Seeds: []int64{1}, Group: "sample"}}}, "invalid group"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.p.validateGenerated()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("validateGenerated() = %v, want error containing %q", err, tt.want)
+			}
+		})
+	}
+}
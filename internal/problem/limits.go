@@ -0,0 +1,86 @@
+package problem
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TimeLimitOverride adjusts a base time limit for a specific language.
+// It either scales the base limit ("3x") or replaces it outright
+// ("2500ms", "2s", or a bare number of milliseconds).
+type TimeLimitOverride struct {
+	// Multiplier scales the base limit (0 if Absolute is used)
+	Multiplier float64
+
+	// Absolute replaces the base limit (0 if Multiplier is used)
+	Absolute time.Duration
+}
+
+// ParseTimeLimitOverride parses an override such as "3x", "1.5x", "2s" or "2500".
+func ParseTimeLimitOverride(s string) (TimeLimitOverride, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return TimeLimitOverride{}, fmt.Errorf("empty time limit override")
+	}
+
+	// Multiplier: "3x"
+	if strings.HasSuffix(s, "x") || strings.HasSuffix(s, "X") {
+		m, err := strconv.ParseFloat(s[:len(s)-1], 64)
+		if err != nil || m <= 0 {
+			return TimeLimitOverride{}, fmt.Errorf("invalid time limit multiplier: %q", s)
+		}
+		return TimeLimitOverride{Multiplier: m}, nil
+	}
+
+	// Bare number: milliseconds, matching time_limit_ms
+	if ms, err := strconv.Atoi(s); err == nil {
+		if ms <= 0 {
+			return TimeLimitOverride{}, fmt.Errorf("invalid time limit: %q", s)
+		}
+		return TimeLimitOverride{Absolute: time.Duration(ms) * time.Millisecond}, nil
+	}
+
+	// Duration: "2s", "1500ms"
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return TimeLimitOverride{}, fmt.Errorf("invalid time limit override: %q", s)
+	}
+	return TimeLimitOverride{Absolute: d}, nil
+}
+
+// UnmarshalYAML parses an override from its string or numeric YAML form.
+func (o *TimeLimitOverride) UnmarshalYAML(value *yaml.Node) error {
+	var s string
+	if err := value.Decode(&s); err != nil {
+		return err
+	}
+	parsed, err := ParseTimeLimitOverride(s)
+	if err != nil {
+		return fmt.Errorf("line %d: %w", value.Line, err)
+	}
+	*o = parsed
+	return nil
+}
+
+// Apply returns the adjusted time limit for the given base limit.
+func (o TimeLimitOverride) Apply(base time.Duration) time.Duration {
+	if o.Absolute > 0 {
+		return o.Absolute
+	}
+	if o.Multiplier > 0 {
+		return time.Duration(float64(base) * o.Multiplier)
+	}
+	return base
+}
+
+// String returns the override in the same form it is written in problem.yaml.
+func (o TimeLimitOverride) String() string {
+	if o.Absolute > 0 {
+		return o.Absolute.String()
+	}
+	return strconv.FormatFloat(o.Multiplier, 'g', -1, 64) + "x"
+}
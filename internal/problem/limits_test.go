@@ -0,0 +1,77 @@
+package problem
+
+import (
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseTimeLimitOverride(t *testing.T) {
+	tests := []struct {
+		input string
+		want  TimeLimitOverride
+	}{
+		{"3x", TimeLimitOverride{Multiplier: 3}},
+		{"1.5X", TimeLimitOverride{Multiplier: 1.5}},
+		{"2500", TimeLimitOverride{Absolute: 2500 * time.Millisecond}},
+		{"2s", TimeLimitOverride{Absolute: 2 * time.Second}},
+		{" 750ms ", TimeLimitOverride{Absolute: 750 * time.Millisecond}},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTimeLimitOverride(tt.input)
+		if err != nil {
+			t.Errorf("ParseTimeLimitOverride(%q) returned error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseTimeLimitOverride(%q) = %+v, want %+v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseTimeLimitOverride_Invalid(t *testing.T) {
+	for _, input := range []string{"", "x", "-2x", "0x", "0", "-5", "fast", "3y"} {
+		if _, err := ParseTimeLimitOverride(input); err == nil {
+			t.Errorf("ParseTimeLimitOverride(%q) expected error, got nil", input)
+		}
+	}
+}
+
+func TestTimeLimitOverride_Apply(t *testing.T) {
+	base := time.Second
+
+	if got := (TimeLimitOverride{Multiplier: 3}).Apply(base); got != 3*time.Second {
+		t.Errorf("3x of 1s = %v, want 3s", got)
+	}
+	if got := (TimeLimitOverride{Absolute: 250 * time.Millisecond}).Apply(base); got != 250*time.Millisecond {
+		t.Errorf("absolute override = %v, want 250ms", got)
+	}
+	if got := (TimeLimitOverride{}).Apply(base); got != base {
+		t.Errorf("empty override = %v, want base %v", got, base)
+	}
+}
+
+func TestTimeLimitOverride_YAML(t *testing.T) {
+	data := []byte("time_limit_overrides:\n  python: 3x\n  java: 2000\n  go: 500ms\n")
+
+	var p Problem
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+
+	if got := p.TimeLimitOverrides["python"]; got.Multiplier != 3 {
+		t.Errorf("python override = %+v, want 3x", got)
+	}
+	if got := p.TimeLimitOverrides["java"]; got.Absolute != 2*time.Second {
+		t.Errorf("java override = %+v, want 2s", got)
+	}
+	if got := p.TimeLimitOverrides["go"]; got.Absolute != 500*time.Millisecond {
+		t.Errorf("go override = %+v, want 500ms", got)
+	}
+
+	if err := yaml.Unmarshal([]byte("time_limit_overrides:\n  python: lots\n"), &p); err == nil {
+		t.Error("expected error for invalid override")
+	}
+}
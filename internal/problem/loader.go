@@ -2,10 +2,12 @@
 package problem
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -14,6 +16,9 @@ import (
 // Loader handles loading problems from a directory.
 type Loader struct {
 	problemsDir string
+
+	// builder builds generated tests missing from the cache (nil = none)
+	builder TestBuilder
 }
 
 // NewLoader creates a new problem loader for the given directory.
@@ -21,9 +26,20 @@ func NewLoader(problemsDir string) *Loader {
 	return &Loader{problemsDir: problemsDir}
 }
 
+// SetTestBuilder sets how generated tests missing from the cache are built.
+// Without a builder, only cached generated tests can be loaded.
+func (l *Loader) SetTestBuilder(b TestBuilder) {
+	l.builder = b
+}
+
+// Dir returns the directory holding a problem's files.
+func (l *Loader) Dir(id string) string {
+	return filepath.Join(l.problemsDir, id)
+}
+
 // Load reads a problem by ID from the problems directory.
 func (l *Loader) Load(id string) (*Problem, error) {
-	problemDir := filepath.Join(l.problemsDir, id)
+	problemDir := l.Dir(id)
 
 	// Check if problem directory exists
 	if _, err := os.Stat(problemDir); os.IsNotExist(err) {
@@ -45,6 +61,48 @@ func (l *Loader) Load(id string) (*Problem, error) {
 	// Set defaults
 	problem.Defaults()
 
+	if err := problem.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid problem.yaml: %w", err)
+	}
+
+	// Programs referenced by problem.yaml must exist
+	if problem.Comparator != "" {
+		checkerPath := filepath.Join(problemDir, problem.Comparator)
+		if _, err := os.Stat(checkerPath); err != nil {
+			return nil, fmt.Errorf("checker program not found: %s", checkerPath)
+		}
+	}
+	if problem.Validator != "" {
+		validatorPath := filepath.Join(problemDir, problem.Validator)
+		if _, err := os.Stat(validatorPath); err != nil {
+			return nil, fmt.Errorf("validator program not found: %s", validatorPath)
+		}
+	}
+	if problem.Scaling != nil {
+		generatorPath := filepath.Join(problemDir, problem.Scaling.Generator)
+		if _, err := os.Stat(generatorPath); err != nil {
+			return nil, fmt.Errorf("scaling generator not found: %s", generatorPath)
+		}
+	}
+	if problem.Reference != "" {
+		referencePath := filepath.Join(problemDir, problem.Reference)
+		if _, err := os.Stat(referencePath); err != nil {
+			return nil, fmt.Errorf("reference solution not found: %s", referencePath)
+		}
+	}
+	for _, g := range problem.Generated {
+		generatorPath := filepath.Join(problemDir, g.Generator)
+		if _, err := os.Stat(generatorPath); err != nil {
+			return nil, fmt.Errorf("test generator not found: %s", generatorPath)
+		}
+	}
+	for _, sol := range problem.Solutions {
+		solutionPath := filepath.Join(problemDir, sol.Path)
+		if _, err := os.Stat(solutionPath); err != nil {
+			return nil, fmt.Errorf("solution not found: %s", solutionPath)
+		}
+	}
+
 	// Ensure ID matches directory name
 	if problem.ID == "" {
 		problem.ID = id
@@ -53,11 +111,18 @@ func (l *Loader) Load(id string) (*Problem, error) {
 	return &problem, nil
 }
 
-// LoadTestCases loads all test cases for a problem.
-func (l *Loader) LoadTestCases(id string) ([]TestCase, error) {
-	problemDir := filepath.Join(l.problemsDir, id)
+// LoadTestCases loads all test cases for a problem, with metadata from
+// sidecar files and the problem's `tests` section applied. Generated tests
+// follow the ones on disk and are built on first use, until ctx is done.
+func (l *Loader) LoadTestCases(ctx context.Context, id string) ([]TestCase, error) {
+	problemDir := l.Dir(id)
 	testsDir := filepath.Join(problemDir, "tests")
 
+	problem, err := l.Load(id)
+	if err != nil {
+		return nil, err
+	}
+
 	var testCases []TestCase
 
 	// Load sample tests
@@ -74,14 +139,49 @@ func (l *Loader) LoadTestCases(id string) ([]TestCase, error) {
 	}
 	testCases = append(testCases, hiddenTests...)
 
+	// Load any other test directories (e.g. one per subtask) in name order
+	entries, err := os.ReadDir(testsDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read tests directory: %w", err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || entry.Name() == "sample" || entry.Name() == "hidden" {
+			continue
+		}
+		groupTests, err := l.loadTestsFromDir(filepath.Join(testsDir, entry.Name()), entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		testCases = append(testCases, groupTests...)
+	}
+
+	generated, err := l.loadGeneratedTests(ctx, id, problem)
+	if err != nil {
+		return nil, err
+	}
+	testCases = append(testCases, generated...)
+
 	if len(testCases) == 0 {
 		return nil, fmt.Errorf("no test cases found for problem: %s", id)
 	}
 
+	seen := make(map[string]bool)
+	for _, tc := range testCases {
+		if seen[tc.Name] {
+			return nil, fmt.Errorf("duplicate test name %s (a generated group clashes with a tests/ directory)", tc.Name)
+		}
+		seen[tc.Name] = true
+	}
+
+	// problem.yaml metadata overrides sidecar files
+	for i := range testCases {
+		testCases[i].Meta = testCases[i].Meta.merge(problem.TestMetaFor(testCases[i].Name))
+	}
+
 	return testCases, nil
 }
 
-// loadTestsFromDir loads test cases from a directory (sample or hidden).
+// loadTestsFromDir loads test cases from a directory (sample, hidden, or a group).
 func (l *Loader) loadTestsFromDir(dir, prefix string) ([]TestCase, error) {
 	entries, err := os.ReadDir(dir)
 	if err != nil {
@@ -125,16 +225,93 @@ func (l *Loader) loadTestsFromDir(dir, prefix string) ([]TestCase, error) {
 			return nil, fmt.Errorf("failed to read %s: %w", outPath, err)
 		}
 
+		meta, err := readTestMeta(filepath.Join(dir, name+".yaml"))
+		if err != nil {
+			return nil, err
+		}
+
+		alternatives, err := readAlternatives(dir, name, entries)
+		if err != nil {
+			return nil, err
+		}
+
 		testCases = append(testCases, TestCase{
-			Name:     fmt.Sprintf("%s/%s", prefix, name),
-			Input:    string(input),
-			Expected: string(expected),
+			Name:         fmt.Sprintf("%s/%s", prefix, name),
+			Input:        string(input),
+			Expected:     string(expected),
+			Sample:       prefix == "sample",
+			Meta:         meta,
+			Alternatives: alternatives,
 		})
 	}
 
 	return testCases, nil
 }
 
+// readAlternatives reads a test's alternative answers (<name>.alt1.out,
+// <name>.alt2.out, ...) in numeric order.
+func readAlternatives(dir, name string, entries []os.DirEntry) ([]string, error) {
+	type alternative struct {
+		n    int
+		path string
+	}
+	var found []alternative
+	for _, entry := range entries {
+		rest, ok := strings.CutPrefix(entry.Name(), name+".alt")
+		if !ok || entry.IsDir() {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSuffix(rest, ".out"))
+		if err != nil || !strings.HasSuffix(rest, ".out") {
+			continue
+		}
+		found = append(found, alternative{n, filepath.Join(dir, entry.Name())})
+	}
+	sort.Slice(found, func(i, k int) bool { return found[i].n < found[k].n })
+
+	var alternatives []string
+	for _, alt := range found {
+		data, err := os.ReadFile(alt.path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", alt.path, err)
+		}
+		alternatives = append(alternatives, string(data))
+	}
+	return alternatives, nil
+}
+
+// SaveTestCase writes a new test case to tests/<group>/ and returns its name
+// (e.g. "stress/3"). Files are numbered after the highest existing number.
+func (l *Loader) SaveTestCase(id, group, input, expected string) (string, error) {
+	dir := filepath.Join(l.Dir(id), "tests", group)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	next := 1
+	for _, entry := range entries {
+		n, err := strconv.Atoi(strings.TrimSuffix(entry.Name(), ".in"))
+		if err == nil && strings.HasSuffix(entry.Name(), ".in") && n >= next {
+			next = n + 1
+		}
+	}
+
+	base := filepath.Join(dir, strconv.Itoa(next))
+	if err := os.WriteFile(base+".in", []byte(input), 0644); err != nil {
+		return "", fmt.Errorf("failed to write test input: %w", err)
+	}
+	if err := os.WriteFile(base+".out", []byte(expected), 0644); err != nil {
+		return "", fmt.Errorf("failed to write test output: %w", err)
+	}
+
+	return fmt.Sprintf("%s/%d", group, next), nil
+}
+
 // List returns all available problem IDs.
 func (l *Loader) List() ([]string, error) {
 	entries, err := os.ReadDir(l.problemsDir)
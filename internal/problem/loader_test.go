@@ -0,0 +1,85 @@
+package problem
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveTestCase(t *testing.T) {
+	dir := t.TempDir()
+	groupDir := filepath.Join(dir, "p", "tests", "stress")
+	if err := os.MkdirAll(groupDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"2.in", "10.in", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(groupDir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	l := NewLoader(dir)
+	name, err := l.SaveTestCase("p", "stress", "1 2\n", "3\n")
+	if err != nil {
+		t.Fatalf("SaveTestCase returned error: %v", err)
+	}
+	if name != "stress/11" {
+		t.Errorf("SaveTestCase name = %q, want stress/11", name)
+	}
+
+	got, err := os.ReadFile(filepath.Join(groupDir, "11.out"))
+	if err != nil || string(got) != "3\n" {
+		t.Errorf("11.out = %q, %v; want %q", got, err, "3\n")
+	}
+}
+
+func TestLoadTestCases_Alternatives(t *testing.T) {
+	dir := t.TempDir()
+	problemDir := filepath.Join(dir, "p")
+	groupDir := filepath.Join(problemDir, "tests", "sample")
+	if err := os.MkdirAll(groupDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"1.in":        "4\n",
+		"1.out":       "1 3\n",
+		"1.alt2.out":  "3 1\n",
+		"1.alt10.out": "2 2\n",
+		"1.alt1.out":  "0 4\n",
+		"1.altx.out":  "ignored\n",
+		"2.in":        "1\n",
+		"2.out":       "0 1\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(groupDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	yaml := "id: p\ntime_limit_ms: 1000\nmemory_limit_mb: 256\n"
+	if err := os.WriteFile(filepath.Join(problemDir, "problem.yaml"), []byte(yaml), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases, err := NewLoader(dir).LoadTestCases(context.Background(), "p")
+	if err != nil {
+		t.Fatalf("LoadTestCases returned error: %v", err)
+	}
+	if len(testCases) != 2 {
+		t.Fatalf("got %d test cases, want 2", len(testCases))
+	}
+
+	want := []string{"0 4\n", "3 1\n", "2 2\n"}
+	got := testCases[0].Alternatives
+	if len(got) != len(want) {
+		t.Fatalf("sample/1 alternatives = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("alternative %d = %q, want %q", i+1, got[i], want[i])
+		}
+	}
+	if len(testCases[1].Alternatives) != 0 {
+		t.Errorf("sample/2 alternatives = %q, want none", testCases[1].Alternatives)
+	}
+}
@@ -0,0 +1,49 @@
+package problem
+
+import (
+	"fmt"
+)
+
+// Scaling describes how to generate inputs of a given size, for estimating
+// a solution's complexity empirically.
+type Scaling struct {
+	// Generator is a program, relative to the problem dir, called as
+	// `generator <seed> <size>` that prints one input of that size
+	Generator string `yaml:"generator"`
+
+	// MaxSize is the largest size allowed by the constraints (e.g. 100000)
+	MaxSize int `yaml:"max_size"`
+
+	// Sizes to measure (default: DefaultSizes)
+	Sizes []int `yaml:"sizes,omitempty"`
+}
+
+// DefaultSizes returns 8 sizes doubling up to MaxSize/8, small enough to run
+// quickly and far enough apart to tell growth rates apart. The time at
+// MaxSize is then extrapolated.
+func (s *Scaling) DefaultSizes() []int {
+	var sizes []int
+	for size := s.MaxSize / 8; size >= 1 && len(sizes) < 8; size /= 2 {
+		sizes = append([]int{size}, sizes...)
+	}
+	return sizes
+}
+
+// validate checks the generator and sizes.
+func (s *Scaling) validate() error {
+	if s.Generator == "" {
+		return fmt.Errorf("scaling: generator is required")
+	}
+	if s.MaxSize <= 0 {
+		return fmt.Errorf("scaling: max_size must be positive")
+	}
+	for i, size := range s.Sizes {
+		if size <= 0 || size > s.MaxSize {
+			return fmt.Errorf("scaling: size %d is outside 1..max_size", size)
+		}
+		if i > 0 && size <= s.Sizes[i-1] {
+			return fmt.Errorf("scaling: sizes must be increasing")
+		}
+	}
+	return nil
+}
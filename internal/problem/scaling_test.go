@@ -0,0 +1,47 @@
+package problem
+
+import (
+	"testing"
+)
+
+func TestValidate_Scaling(t *testing.T) {
+	valid := &Problem{ID: "test", Scaling: &Scaling{Generator: "gen.py", MaxSize: 1000, Sizes: []int{10, 100}}}
+	valid.Defaults()
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("Expected valid scaling, got: %v", err)
+	}
+
+	invalid := map[string]*Scaling{
+		"no generator":       {MaxSize: 1000},
+		"no max size":        {Generator: "gen.py"},
+		"size above max":     {Generator: "gen.py", MaxSize: 100, Sizes: []int{10, 1000}},
+		"decreasing sizes":   {Generator: "gen.py", MaxSize: 1000, Sizes: []int{100, 10}},
+		"non-positive sizes": {Generator: "gen.py", MaxSize: 1000, Sizes: []int{0, 10}},
+	}
+	for name, scaling := range invalid {
+		p := &Problem{ID: "test", Scaling: scaling}
+		p.Defaults()
+		if err := p.Validate(); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+}
+
+func TestScaling_DefaultSizes(t *testing.T) {
+	s := &Scaling{MaxSize: 100000}
+	want := []int{97, 195, 390, 781, 1562, 3125, 6250, 12500}
+
+	got := s.DefaultSizes()
+	if len(got) != len(want) {
+		t.Fatalf("DefaultSizes() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("DefaultSizes() = %v, want %v", got, want)
+		}
+	}
+
+	if got := (&Scaling{MaxSize: 20}).DefaultSizes(); len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Errorf("DefaultSizes() for max 20 = %v, want [1 2]", got)
+	}
+}
@@ -0,0 +1,110 @@
+package problem
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Signature declares a function-signature problem: solutions implement a
+// function instead of reading stdin, and the judge wraps them in a
+// per-language harness. Each test input holds one argument per line and
+// the expected output is the return value, both as compact JSON.
+type Signature struct {
+	// Function is the name of the function solutions implement
+	Function string `yaml:"function"`
+
+	// Params are the function's parameters, in call order
+	Params []Param `yaml:"params"`
+
+	// Returns is the type of the return value
+	Returns Type `yaml:"returns"`
+}
+
+// String formats the signature as "name(param: type, ...) -> type".
+func (s *Signature) String() string {
+	params := make([]string, len(s.Params))
+	for i, p := range s.Params {
+		params[i] = fmt.Sprintf("%s: %s", p.Name, p.Type)
+	}
+	return fmt.Sprintf("%s(%s) -> %s", s.Function, strings.Join(params, ", "), s.Returns)
+}
+
+// Param is a named, typed function parameter.
+type Param struct {
+	Name string `yaml:"name"`
+	Type Type   `yaml:"type"`
+}
+
+// Type is a value type in a function signature: a scalar ("int", "float",
+// "bool", "string"), an array of scalars or arrays ("int[]", "int[][]"),
+// a linked list of ints ("list") or a binary tree of ints ("tree").
+type Type string
+
+// Value types; arrays append "[]" to a scalar or array type
+const (
+	TypeInt    Type = "int"
+	TypeFloat  Type = "float"
+	TypeBool   Type = "bool"
+	TypeString Type = "string"
+	TypeList   Type = "list" // Linked list, written as an array: [1,2,3]
+	TypeTree   Type = "tree" // Binary tree, written in level order: [1,null,2]
+)
+
+// Elem returns the element type of an array type.
+func (t Type) Elem() (Type, bool) {
+	elem, ok := strings.CutSuffix(string(t), "[]")
+	return Type(elem), ok
+}
+
+// validate checks that the type is one the harnesses support.
+func (t Type) validate() error {
+	base := t
+	for {
+		elem, ok := base.Elem()
+		if !ok {
+			break
+		}
+		base = elem
+	}
+	switch base {
+	case TypeInt, TypeFloat, TypeBool, TypeString:
+		return nil
+	case TypeList, TypeTree:
+		if base == t {
+			return nil
+		}
+		return fmt.Errorf("type %q: arrays of %s are not supported", t, base)
+	}
+	return fmt.Errorf("unknown type %q (available: int, float, bool, string, list, tree, and arrays such as int[] or int[][])", t)
+}
+
+// identifier matches names that are valid in every supported language
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// validate checks the function name, parameters and types.
+func (s *Signature) validate() error {
+	if !identifier.MatchString(s.Function) {
+		return fmt.Errorf("signature: invalid function name %q", s.Function)
+	}
+	seen := make(map[string]bool)
+	for i, param := range s.Params {
+		if !identifier.MatchString(param.Name) {
+			return fmt.Errorf("signature: param %d: invalid name %q", i+1, param.Name)
+		}
+		if seen[param.Name] {
+			return fmt.Errorf("signature: duplicate param %q", param.Name)
+		}
+		seen[param.Name] = true
+		if err := param.Type.validate(); err != nil {
+			return fmt.Errorf("signature: param %s: %w", param.Name, err)
+		}
+	}
+	if s.Returns == "" {
+		return fmt.Errorf("signature: returns is required")
+	}
+	if err := s.Returns.validate(); err != nil {
+		return fmt.Errorf("signature: returns: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package problem
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestSignature_Parse(t *testing.T) {
+	data := `
+function: twoSum
+params:
+  - name: nums
+    type: int[]
+  - name: target
+    type: int
+returns: int[]
+`
+	var sig Signature
+	if err := yaml.Unmarshal([]byte(data), &sig); err != nil {
+		t.Fatal(err)
+	}
+	if err := sig.validate(); err != nil {
+		t.Fatalf("validate returned error: %v", err)
+	}
+	if sig.Function != "twoSum" || len(sig.Params) != 2 || sig.Params[0].Type != "int[]" || sig.Returns != "int[]" {
+		t.Errorf("parsed signature = %+v", sig)
+	}
+}
+
+func TestSignature_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		sig     Signature
+		wantErr string
+	}{
+		{"scalars", Signature{Function: "f", Params: []Param{{"a", TypeInt}, {"b", TypeString}}, Returns: TypeBool}, ""},
+		{"matrix", Signature{Function: "f", Params: []Param{{"grid", "int[][]"}}, Returns: "float[]"}, ""},
+		{"list and tree", Signature{Function: "f", Params: []Param{{"head", TypeList}}, Returns: TypeTree}, ""},
+		{"no params", Signature{Function: "f", Returns: TypeInt}, ""},
+		{"bad function", Signature{Function: "two-sum", Returns: TypeInt}, "invalid function name"},
+		{"bad param name", Signature{Function: "f", Params: []Param{{"1x", TypeInt}}, Returns: TypeInt}, "invalid name"},
+		{"duplicate param", Signature{Function: "f", Params: []Param{{"a", TypeInt}, {"a", TypeInt}}, Returns: TypeInt}, "duplicate param"},
+		{"unknown type", Signature{Function: "f", Params: []Param{{"a", "uint"}}, Returns: TypeInt}, "unknown type"},
+		{"array of lists", Signature{Function: "f", Params: []Param{{"a", "list[]"}}, Returns: TypeInt}, "not supported"},
+		{"missing returns", Signature{Function: "f"}, "returns is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.sig.validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validate returned error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validate error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSignature_String(t *testing.T) {
+	sig := &Signature{Function: "twoSum", Params: []Param{{"nums", "int[]"}, {"target", TypeInt}}, Returns: "int[]"}
+	if got, want := sig.String(), "twoSum(nums: int[], target: int) -> int[]"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
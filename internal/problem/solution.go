@@ -0,0 +1,40 @@
+package problem
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Solution is a reference solution with the verdict it must get, used by
+// `judge verify` to check that tests and limits tell good from bad.
+type Solution struct {
+	// Path to the solution file, relative to the problem dir
+	Path string `yaml:"path"`
+
+	// Expected is "AC" (every test passes) or a failing verdict that at
+	// least one test must get, with no other kind of failure
+	Expected string `yaml:"expected"`
+}
+
+// ExpectedVerdicts lists the verdicts a solution can be expected to get
+var ExpectedVerdicts = []string{"AC", "WA", "PE", "TLE", "MLE", "RE"}
+
+// validateSolutions checks that each solution has a path and a known verdict.
+func (p *Problem) validateSolutions() error {
+	for i, sol := range p.Solutions {
+		if sol.Path == "" {
+			return fmt.Errorf("solution %d has no path", i+1)
+		}
+		known := false
+		for _, v := range ExpectedVerdicts {
+			if sol.Expected == v {
+				known = true
+			}
+		}
+		if !known {
+			return fmt.Errorf("solution %s: unknown expected verdict %q (available: %s)",
+				sol.Path, sol.Expected, strings.Join(ExpectedVerdicts, ", "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package problem
+
+import (
+	"testing"
+)
+
+func TestValidate_Solutions(t *testing.T) {
+	valid := &Problem{ID: "test", Solutions: []Solution{
+		{Path: "correct.py", Expected: "AC"},
+		{Path: "naive.py", Expected: "TLE"},
+	}}
+	valid.Defaults()
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("Expected valid solutions, got: %v", err)
+	}
+
+	invalid := map[string]Solution{
+		"no path":          {Expected: "AC"},
+		"no verdict":       {Path: "a.py"},
+		"unknown verdict":  {Path: "a.py", Expected: "accepted"},
+		"internal verdict": {Path: "a.py", Expected: "SE"},
+	}
+	for name, sol := range invalid {
+		p := &Problem{ID: "test", Solutions: []Solution{sol}}
+		p.Defaults()
+		if err := p.Validate(); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+}
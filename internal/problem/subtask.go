@@ -0,0 +1,84 @@
+package problem
+
+import (
+	"fmt"
+	"path"
+)
+
+// Subtask is a group of test cases scored together (IOI style).
+type Subtask struct {
+	Name      string        `yaml:"name"`
+	Points    float64       `yaml:"points"`
+	Tests     []string      `yaml:"tests"`                // Test name patterns, e.g. "hidden/*"
+	DependsOn []string      `yaml:"depends_on,omitempty"` // Subtasks that must be fully solved first
+	Scoring   ScoringPolicy `yaml:"scoring,omitempty"`
+}
+
+// ScoringPolicy defines how a subtask's points are derived from its tests.
+type ScoringPolicy string
+
+const (
+	ScoringAllOrNothing ScoringPolicy = "all-or-nothing" // Full points only if every test passes
+	ScoringMin          ScoringPolicy = "min"            // Points scaled by the lowest test score
+	ScoringSum          ScoringPolicy = "sum"            // Points scaled by the weighted mean test score
+)
+
+// Matches reports whether a test case belongs to the subtask.
+func (s *Subtask) Matches(testName string) bool {
+	for _, pattern := range s.Tests {
+		if MatchTestName(pattern, testName) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchTestName reports whether a test name matches a pattern such as
+// "hidden/3", "hidden/*" or "sample/[12]".
+func MatchTestName(pattern, testName string) bool {
+	matched, err := path.Match(pattern, testName)
+	return err == nil && matched
+}
+
+// validateSubtasks checks subtask names, patterns, scoring and dependencies.
+// Dependencies must refer to subtasks declared earlier, which rules out cycles.
+func (p *Problem) validateSubtasks() error {
+	seen := make(map[string]bool)
+
+	for i, st := range p.Subtasks {
+		if st.Name == "" {
+			return fmt.Errorf("subtask %d has no name", i+1)
+		}
+		if seen[st.Name] {
+			return fmt.Errorf("duplicate subtask name: %s", st.Name)
+		}
+		if st.Points < 0 {
+			return fmt.Errorf("subtask %s: points must not be negative", st.Name)
+		}
+		if len(st.Tests) == 0 {
+			return fmt.Errorf("subtask %s: no tests listed", st.Name)
+		}
+		for _, pattern := range st.Tests {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("subtask %s: invalid test pattern %q", st.Name, pattern)
+			}
+		}
+
+		switch st.Scoring {
+		case ScoringAllOrNothing, ScoringMin, ScoringSum:
+		default:
+			return fmt.Errorf("subtask %s: unknown scoring policy %q (available: %s, %s, %s)",
+				st.Name, st.Scoring, ScoringAllOrNothing, ScoringMin, ScoringSum)
+		}
+
+		for _, dep := range st.DependsOn {
+			if !seen[dep] {
+				return fmt.Errorf("subtask %s depends on %q, which must be declared before it", st.Name, dep)
+			}
+		}
+
+		seen[st.Name] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package problem
+
+import (
+	"testing"
+)
+
+func TestMatchTestName(t *testing.T) {
+	tests := []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"hidden/3", "hidden/3", true},
+		{"hidden/*", "hidden/3", true},
+		{"hidden/*", "sample/1", false},
+		{"*/1", "sample/1", true},
+		{"sample/[12]", "sample/2", true},
+		{"sample/[12]", "sample/3", false},
+		{"hidden/*", "hidden/sub/1", false},
+	}
+
+	for _, tt := range tests {
+		if got := MatchTestName(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("MatchTestName(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestValidate_Subtasks(t *testing.T) {
+	valid := []Subtask{
+		{Name: "small", Points: 30, Tests: []string{"sample/*", "hidden/6"}},
+		{Name: "large", Points: 70, Tests: []string{"hidden/*"}, DependsOn: []string{"small"}, Scoring: ScoringMin},
+	}
+
+	p := &Problem{ID: "test", Subtasks: valid}
+	p.Defaults()
+	if err := p.Validate(); err != nil {
+		t.Fatalf("Expected valid subtasks, got: %v", err)
+	}
+	if p.Subtasks[0].Scoring != ScoringAllOrNothing {
+		t.Errorf("Expected default scoring %q, got %q", ScoringAllOrNothing, p.Subtasks[0].Scoring)
+	}
+
+	invalid := map[string][]Subtask{
+		"missing name":       {{Points: 10, Tests: []string{"*"}}},
+		"duplicate name":     {{Name: "a", Tests: []string{"*"}}, {Name: "a", Tests: []string{"*"}}},
+		"negative points":    {{Name: "a", Points: -1, Tests: []string{"*"}}},
+		"no tests":           {{Name: "a", Points: 10}},
+		"bad pattern":        {{Name: "a", Tests: []string{"hidden/["}}},
+		"unknown scoring":    {{Name: "a", Tests: []string{"*"}, Scoring: "sum-of-squares"}},
+		"unknown dependency": {{Name: "a", Tests: []string{"*"}, DependsOn: []string{"b"}}},
+		"forward dependency": {{Name: "a", Tests: []string{"*"}, DependsOn: []string{"b"}}, {Name: "b", Tests: []string{"*"}}},
+	}
+
+	for name, subtasks := range invalid {
+		p := &Problem{ID: "test", Subtasks: subtasks}
+		p.Defaults()
+		if err := p.Validate(); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package problem
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestMeta is optional metadata for a test case. It comes from a sidecar
+// file next to the input (tests/hidden/3.yaml for tests/hidden/3.in) and
+// from the `tests` section of problem.yaml, which takes precedence.
+type TestMeta struct {
+	// Description says what the test covers, e.g. "max n, all negative"
+	Description string `yaml:"description,omitempty"`
+
+	// TimeLimitMS replaces the problem's time limit for this test (0 = no override)
+	TimeLimitMS int `yaml:"time_limit_ms,omitempty"`
+
+	// MemoryLimitMB replaces the problem's memory limit for this test (0 = no override)
+	MemoryLimitMB int `yaml:"memory_limit_mb,omitempty"`
+
+	// Weight is the test's share of a subtask scored with "sum" (0 = weight 1)
+	Weight float64 `yaml:"weight,omitempty"`
+
+	// Hidden keeps the test's input and expected output out of results
+	Hidden bool `yaml:"hidden,omitempty"`
+}
+
+// TestWeight returns the test's weight, defaulting to 1.
+func (m TestMeta) TestWeight() float64 {
+	if m.Weight > 0 {
+		return m.Weight
+	}
+	return 1
+}
+
+// merge overlays the fields set in other onto m.
+func (m TestMeta) merge(other TestMeta) TestMeta {
+	if other.Description != "" {
+		m.Description = other.Description
+	}
+	if other.TimeLimitMS != 0 {
+		m.TimeLimitMS = other.TimeLimitMS
+	}
+	if other.MemoryLimitMB != 0 {
+		m.MemoryLimitMB = other.MemoryLimitMB
+	}
+	if other.Weight != 0 {
+		m.Weight = other.Weight
+	}
+	if other.Hidden {
+		m.Hidden = true
+	}
+	return m
+}
+
+// validate checks that limits and weights are usable.
+func (m TestMeta) validate() error {
+	if m.TimeLimitMS < 0 {
+		return fmt.Errorf("time_limit_ms must not be negative")
+	}
+	if m.MemoryLimitMB < 0 {
+		return fmt.Errorf("memory_limit_mb must not be negative")
+	}
+	if m.Weight < 0 {
+		return fmt.Errorf("weight must not be negative")
+	}
+	return nil
+}
+
+// TestMetaFor returns the problem.yaml metadata for a test case. Entries
+// keyed by a pattern ("hidden/*") apply first, in sorted order, and an
+// entry keyed by the exact test name applies last.
+func (p *Problem) TestMetaFor(testName string) TestMeta {
+	var patterns []string
+	for key := range p.Tests {
+		if key != testName && MatchTestName(key, testName) {
+			patterns = append(patterns, key)
+		}
+	}
+	sort.Strings(patterns)
+
+	var meta TestMeta
+	for _, key := range patterns {
+		meta = meta.merge(p.Tests[key])
+	}
+	if exact, ok := p.Tests[testName]; ok {
+		meta = meta.merge(exact)
+	}
+	return meta
+}
+
+// readTestMeta reads a sidecar metadata file, returning empty metadata if it does not exist.
+func readTestMeta(path string) (TestMeta, error) {
+	var meta TestMeta
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return meta, nil
+	}
+	if err != nil {
+		return meta, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if err := yaml.Unmarshal(data, &meta); err != nil {
+		return meta, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if err := meta.validate(); err != nil {
+		return meta, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	return meta, nil
+}
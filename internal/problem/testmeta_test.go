@@ -0,0 +1,40 @@
+package problem
+
+import (
+	"testing"
+)
+
+func TestTestMetaFor(t *testing.T) {
+	p := &Problem{Tests: map[string]TestMeta{
+		"*/*":      {Description: "any", Weight: 1},
+		"hidden/*": {Description: "hidden", TimeLimitMS: 2000},
+		"hidden/3": {Description: "max n", Hidden: true},
+	}}
+
+	got := p.TestMetaFor("hidden/3")
+	want := TestMeta{Description: "max n", TimeLimitMS: 2000, Weight: 1, Hidden: true}
+	if got != want {
+		t.Errorf("TestMetaFor(hidden/3) = %+v, want %+v", got, want)
+	}
+
+	if got := p.TestMetaFor("sample/1"); got.Description != "any" || got.TimeLimitMS != 0 {
+		t.Errorf("TestMetaFor(sample/1) = %+v, want only the */* entry", got)
+	}
+}
+
+func TestValidate_TestMeta(t *testing.T) {
+	invalid := map[string]map[string]TestMeta{
+		"bad pattern":     {"hidden/[": {}},
+		"negative time":   {"hidden/1": {TimeLimitMS: -1}},
+		"negative memory": {"hidden/1": {MemoryLimitMB: -1}},
+		"negative weight": {"hidden/1": {Weight: -2}},
+	}
+
+	for name, tests := range invalid {
+		p := &Problem{ID: "test", Tests: tests}
+		p.Defaults()
+		if err := p.Validate(); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+}
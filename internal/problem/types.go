@@ -1,5 +1,14 @@
 package problem
 
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/marv972228/sandbox_judge/internal/compare"
+)
+
 // Problem represents a coding problem with metadata and test cases.
 type Problem struct {
 	ID          string     `yaml:"id"`
@@ -17,10 +26,48 @@ type Problem struct {
 	TimeLimitMS   int      `yaml:"time_limit_ms"`
 	MemoryLimitMB int      `yaml:"memory_limit_mb"`
 
+	// Serial forces test cases to run one at a time (for timing-sensitive problems)
+	Serial bool `yaml:"serial,omitempty"`
+
+	// Per-language time limits, e.g. {python: 3x, java: 2000ms}
+	TimeLimitOverrides map[string]TimeLimitOverride `yaml:"time_limit_overrides,omitempty"`
+
 	// Comparison settings
-	Comparison     ComparisonMode `yaml:"comparison"`
-	FloatTolerance float64        `yaml:"float_tolerance,omitempty"`
-	Comparator     string         `yaml:"comparator,omitempty"`
+	Comparison      ComparisonMode `yaml:"comparison"`
+	FloatTolerance  float64        `yaml:"float_tolerance,omitempty"`
+	UnorderedTokens bool           `yaml:"unordered_tokens,omitempty"` // With "unordered": ignore token order within lines
+	Comparator      string         `yaml:"comparator,omitempty"`       // Checker program for "custom", relative to the problem dir
+
+	// Limits for the custom checker program
+	CheckerTimeLimitMS   int `yaml:"checker_time_limit_ms,omitempty"`
+	CheckerMemoryLimitMB int `yaml:"checker_memory_limit_mb,omitempty"`
+
+	// Subtasks group test cases for partial scoring (IOI style)
+	Subtasks []Subtask `yaml:"subtasks,omitempty"`
+
+	// Validator is a program, relative to the problem dir, that reads an input
+	// on stdin and exits 0 only if it satisfies the constraints
+	Validator string `yaml:"validator,omitempty"`
+
+	// Scaling generates inputs of increasing size for complexity estimation
+	Scaling *Scaling `yaml:"scaling,omitempty"`
+
+	// Reference is a correct solution, relative to the problem dir, that
+	// produces the expected outputs of generated tests
+	Reference string `yaml:"reference,omitempty"`
+
+	// Generated declares hidden tests built from generators and Reference
+	Generated []GeneratedTests `yaml:"generated,omitempty"`
+
+	// Signature makes solutions implement a function instead of reading
+	// stdin (nil = stdin/stdout problem)
+	Signature *Signature `yaml:"signature,omitempty"`
+
+	// Solutions are reference solutions with expected verdicts, checked by `judge verify`
+	Solutions []Solution `yaml:"solutions,omitempty"`
+
+	// Tests holds per-test metadata keyed by test name or pattern (e.g. "hidden/3", "hidden/*")
+	Tests map[string]TestMeta `yaml:"tests,omitempty"`
 
 	// Examples shown in problem description
 	Examples []Example `yaml:"examples"`
@@ -58,6 +105,16 @@ type TestCase struct {
 	Name     string // e.g., "sample/1" or "hidden/edge_case"
 	Input    string
 	Expected string
+	Sample   bool     // Loaded from tests/sample
+	Meta     TestMeta // Optional description, limits, weight and visibility
+
+	// Alternatives are other accepted outputs, from <name>.alt<K>.out files
+	Alternatives []string
+}
+
+// TimeLimit returns the problem's base time limit.
+func (p *Problem) TimeLimit() time.Duration {
+	return time.Duration(p.TimeLimitMS) * time.Millisecond
 }
 
 // Defaults sets default values for optional fields.
@@ -71,4 +128,73 @@ func (p *Problem) Defaults() {
 	if p.Comparison == "" {
 		p.Comparison = CompareDefault
 	}
+	if p.CheckerTimeLimitMS == 0 {
+		p.CheckerTimeLimitMS = 5000 // 5 seconds default
+	}
+	if p.CheckerMemoryLimitMB == 0 {
+		p.CheckerMemoryLimitMB = 256 // 256 MB default
+	}
+	for i := range p.Generated {
+		if p.Generated[i].Group == "" {
+			p.Generated[i].Group = DefaultGeneratedGroup
+		}
+	}
+	for i := range p.Subtasks {
+		if p.Subtasks[i].Scoring == "" {
+			p.Subtasks[i].Scoring = ScoringAllOrNothing
+		}
+	}
+}
+
+// Validate checks settings that cannot be caught by YAML parsing alone.
+func (p *Problem) Validate() error {
+	if err := p.validateComparison(); err != nil {
+		return err
+	}
+	if p.Scaling != nil {
+		if err := p.Scaling.validate(); err != nil {
+			return err
+		}
+	}
+	if err := p.validateSolutions(); err != nil {
+		return err
+	}
+	if err := p.validateGenerated(); err != nil {
+		return err
+	}
+	if p.Signature != nil {
+		if err := p.Signature.validate(); err != nil {
+			return err
+		}
+	}
+	for key, meta := range p.Tests {
+		if _, err := path.Match(key, ""); err != nil {
+			return fmt.Errorf("tests: invalid test pattern %q", key)
+		}
+		if err := meta.validate(); err != nil {
+			return fmt.Errorf("tests: %s: %w", key, err)
+		}
+	}
+	return p.validateSubtasks()
+}
+
+// validateComparison checks that the comparison mode can be used.
+func (p *Problem) validateComparison() error {
+	if p.Comparison == CompareCustom {
+		// Custom checkers are programs run by the judge, not registered comparators
+		if p.Comparator == "" {
+			return fmt.Errorf("comparison %q requires a comparator program", p.Comparison)
+		}
+		return nil
+	}
+
+	if p.Comparator != "" {
+		return fmt.Errorf("comparator %q is only used with comparison %q", p.Comparator, CompareCustom)
+	}
+
+	if !compare.Registered(string(p.Comparison)) {
+		return fmt.Errorf("unknown comparison mode %q (available: %s)",
+			p.Comparison, strings.Join(compare.Modes(), ", "))
+	}
+	return nil
 }
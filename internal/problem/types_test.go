@@ -0,0 +1,41 @@
+package problem
+
+import (
+	"testing"
+)
+
+func TestValidate_ComparisonMode(t *testing.T) {
+	p := &Problem{ID: "test"}
+	p.Defaults()
+	if err := p.Validate(); err != nil {
+		t.Errorf("Expected default comparison to be valid, got: %v", err)
+	}
+
+	p.Comparison = CompareStrict
+	if err := p.Validate(); err != nil {
+		t.Errorf("Expected strict comparison to be valid, got: %v", err)
+	}
+
+	p.Comparison = "bogus"
+	if err := p.Validate(); err == nil {
+		t.Error("Expected error for unknown comparison mode")
+	}
+}
+
+func TestValidate_CustomComparator(t *testing.T) {
+	p := &Problem{ID: "test", Comparison: CompareCustom}
+	p.Defaults()
+	if err := p.Validate(); err == nil {
+		t.Error("Expected error for custom comparison without a comparator program")
+	}
+
+	p.Comparator = "checker.py"
+	if err := p.Validate(); err != nil {
+		t.Errorf("Expected custom comparison with checker to be valid, got: %v", err)
+	}
+
+	p.Comparison = CompareDefault
+	if err := p.Validate(); err == nil {
+		t.Error("Expected error for comparator program without custom comparison")
+	}
+}
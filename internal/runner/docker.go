@@ -3,8 +3,10 @@ package runner
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"os"
 	"path/filepath"
 	"strings"
 	"time"
@@ -68,6 +70,7 @@ func (r *DockerRunner) Run(ctx context.Context, config RunConfig) (*RunResult, e
 
 	// Prepare the command
 	cmd := r.buildCommand(langConfig.RunCmd, "/sandbox/solution"+langConfig.FileExtension)
+	cmd = append(cmd, config.Args...)
 
 	// Get absolute path for source file
 	absSourcePath, err := filepath.Abs(config.SourcePath)
@@ -78,6 +81,35 @@ func (r *DockerRunner) Run(ctx context.Context, config RunConfig) (*RunResult, e
 		}, nil
 	}
 
+	// Source file mount
+	mounts := []mount.Mount{
+		{
+			Type:     mount.TypeBind,
+			Source:   absSourcePath,
+			Target:   "/sandbox/solution" + langConfig.FileExtension,
+			ReadOnly: true,
+		},
+	}
+
+	// Extra files mount
+	if len(config.Files) > 0 {
+		filesDir, err := writeFiles(config.Files)
+		if err != nil {
+			return &RunResult{
+				Verdict: VerdictSystemError,
+				Error:   fmt.Errorf("failed to prepare files: %w", err),
+			}, nil
+		}
+		defer os.RemoveAll(filesDir)
+
+		mounts = append(mounts, mount.Mount{
+			Type:     mount.TypeBind,
+			Source:   filesDir,
+			Target:   FilesDir,
+			ReadOnly: true,
+		})
+	}
+
 	// Container configuration
 	containerConfig := &container.Config{
 		Image:        langConfig.Image,
@@ -94,14 +126,7 @@ func (r *DockerRunner) Run(ctx context.Context, config RunConfig) (*RunResult, e
 
 	// Host configuration with resource limits
 	hostConfig := &container.HostConfig{
-		Mounts: []mount.Mount{
-			{
-				Type:     mount.TypeBind,
-				Source:   absSourcePath,
-				Target:   "/sandbox/solution" + langConfig.FileExtension,
-				ReadOnly: true,
-			},
-		},
+		Mounts: mounts,
 		// Security options
 		NetworkMode: "none", // No network access
 		AutoRemove:  true,   // Clean up after exit
@@ -155,6 +180,14 @@ func (r *DockerRunner) Run(ctx context.Context, config RunConfig) (*RunResult, e
 		}, nil
 	}
 
+	// Sample memory usage until the container exits, if asked to
+	statsCtx, stopStats := context.WithCancel(ctx)
+	defer stopStats()
+	var peakMemory <-chan int64
+	if config.SampleMemory {
+		peakMemory = r.watchMemory(statsCtx, containerID)
+	}
+
 	// Write stdin
 	go func() {
 		defer attachResp.CloseWrite()
@@ -198,6 +231,11 @@ func (r *DockerRunner) Run(ctx context.Context, config RunConfig) (*RunResult, e
 		result.Stdout = stdout.String()
 		result.Stderr = stderr.String()
 
+		if peakMemory != nil {
+			stopStats()
+			result.MemoryUsed = <-peakMemory
+		}
+
 		// Determine verdict based on exit code
 		if status.StatusCode == 0 {
 			result.Verdict = VerdictAccepted // Will be compared later
@@ -213,6 +251,45 @@ func (r *DockerRunner) Run(ctx context.Context, config RunConfig) (*RunResult, e
 	return &result, nil
 }
 
+// watchMemory streams a container's stats and sends the peak memory usage
+// in bytes once the stream ends or ctx is cancelled. The daemon's own peak
+// (max_usage_in_bytes on cgroup v1, memory.peak on cgroup v2 where the
+// daemon reports it) is preferred: Docker samples about once a second, so
+// the highest current usage seen misses short spikes and is only the
+// fallback.
+func (r *DockerRunner) watchMemory(ctx context.Context, containerID string) <-chan int64 {
+	peak := make(chan int64, 1)
+
+	go func() {
+		var reported, sampled int64
+		defer func() {
+			if reported > 0 {
+				peak <- reported
+			} else {
+				peak <- sampled
+			}
+		}()
+
+		stats, err := r.client.ContainerStats(ctx, containerID, true)
+		if err != nil {
+			return
+		}
+		defer stats.Body.Close()
+
+		decoder := json.NewDecoder(stats.Body)
+		for {
+			var sample types.StatsJSON
+			if err := decoder.Decode(&sample); err != nil {
+				return
+			}
+			reported = max(reported, int64(sample.MemoryStats.MaxUsage))
+			sampled = max(sampled, int64(sample.MemoryStats.Usage))
+		}
+	}()
+
+	return peak
+}
+
 // Supported returns the list of supported languages
 func (r *DockerRunner) Supported() []string {
 	languages := make([]string, 0, len(r.configs))
@@ -222,6 +299,12 @@ func (r *DockerRunner) Supported() []string {
 	return languages
 }
 
+// Language returns the configuration for a language
+func (r *DockerRunner) Language(name string) (LanguageConfig, bool) {
+	cfg, ok := r.configs[name]
+	return cfg, ok
+}
+
 // Cleanup releases Docker client resources
 func (r *DockerRunner) Cleanup() error {
 	return r.client.Close()
@@ -283,6 +366,44 @@ func (r *DockerRunner) buildCommand(cmdTemplate []string, sourcePath string) []s
 	return cmd
 }
 
+// The container runs as a different user, so everything mounted into it
+// must be world-readable
+const (
+	sandboxDirMode  = 0o755
+	sandboxFileMode = 0o644
+)
+
+// WriteSandboxFile writes a file that will be mounted into the sandbox,
+// such as a generated source file, so that the sandbox user can read it
+func WriteSandboxFile(path, content string) error {
+	return os.WriteFile(path, []byte(content), sandboxFileMode)
+}
+
+// writeFiles writes files into a new temporary directory readable by the sandbox user
+func writeFiles(files map[string]string) (string, error) {
+	dir, err := os.MkdirTemp("", "sandbox-judge-files-")
+	if err != nil {
+		return "", err
+	}
+	if err := os.Chmod(dir, sandboxDirMode); err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+
+	for name, content := range files {
+		if name != filepath.Base(name) {
+			os.RemoveAll(dir)
+			return "", fmt.Errorf("invalid file name: %s", name)
+		}
+		if err := WriteSandboxFile(filepath.Join(dir, name), content); err != nil {
+			os.RemoveAll(dir)
+			return "", err
+		}
+	}
+
+	return dir, nil
+}
+
 // createBuildContext creates a tar archive for Docker build
 func createBuildContext(dockerfilePath string) (io.Reader, error) {
 	// For simplicity, we'll use the Docker CLI approach
@@ -16,17 +16,22 @@ var (
 	ErrImageNotFound       = errors.New("runner image not found")
 )
 
+// FilesDir is the directory inside the sandbox where RunConfig.Files are placed
+const FilesDir = "/sandbox/files"
+
 // Verdict represents the result of a test case execution
 type Verdict string
 
 const (
-	VerdictAccepted            Verdict = "AC"  // Correct answer
-	VerdictWrongAnswer         Verdict = "WA"  // Incorrect output
-	VerdictTimeLimitExceeded   Verdict = "TLE" // Exceeded time limit
-	VerdictMemoryLimitExceeded Verdict = "MLE" // Exceeded memory limit
-	VerdictRuntimeError        Verdict = "RE"  // Crashed or non-zero exit
-	VerdictCompilationError    Verdict = "CE"  // Failed to compile
-	VerdictSystemError         Verdict = "SE"  // Internal judge error
+	VerdictAccepted            Verdict = "AC"   // Correct answer
+	VerdictWrongAnswer         Verdict = "WA"   // Incorrect output
+	VerdictPresentationError   Verdict = "PE"   // Right answer, wrong format
+	VerdictTimeLimitExceeded   Verdict = "TLE"  // Exceeded time limit
+	VerdictMemoryLimitExceeded Verdict = "MLE"  // Exceeded memory limit
+	VerdictRuntimeError        Verdict = "RE"   // Crashed or non-zero exit
+	VerdictCompilationError    Verdict = "CE"   // Failed to compile
+	VerdictSystemError         Verdict = "SE"   // Internal judge error
+	VerdictSkipped             Verdict = "SKIP" // Not run due to the evaluation policy
 )
 
 // RunConfig specifies execution parameters
@@ -48,6 +53,16 @@ type RunConfig struct {
 
 	// WorkDir is an optional working directory inside the container
 	WorkDir string
+
+	// Args are extra command-line arguments passed to the program
+	Args []string
+
+	// Files are extra read-only files placed in FilesDir, keyed by file name
+	Files map[string]string
+
+	// SampleMemory enables measuring peak memory (RunResult.MemoryUsed),
+	// which costs a stats stream per run
+	SampleMemory bool
 }
 
 // RunResult contains the outcome of a code execution
@@ -64,7 +79,8 @@ type RunResult struct {
 	// Duration is the wall clock time taken
 	Duration time.Duration
 
-	// MemoryUsed is peak memory usage in bytes (if measurable)
+	// MemoryUsed is peak memory usage in bytes (0 unless SampleMemory was
+	// set and the runner could measure it)
 	MemoryUsed int64
 
 	// Verdict is the high-level result
@@ -82,6 +98,9 @@ type Runner interface {
 	// Supported returns the list of supported language identifiers
 	Supported() []string
 
+	// Language returns the configuration for a language identifier
+	Language(name string) (LanguageConfig, bool)
+
 	// Cleanup releases any resources held by the runner
 	Cleanup() error
 }
@@ -100,20 +119,28 @@ type LanguageConfig struct {
 
 	// FileExtension is the expected source file extension
 	FileExtension string
+
+	// TimeMultiplier scales every problem's time limit (0 = no scaling)
+	TimeMultiplier float64
+
+	// TimeLimit replaces every problem's time limit (0 = use the problem's)
+	TimeLimit time.Duration
 }
 
 // DefaultLanguageConfigs provides default configurations for common languages
 var DefaultLanguageConfigs = map[string]LanguageConfig{
 	"python": {
-		Image:         "sandbox-judge-python:latest",
-		CompileCmd:    nil, // Interpreted
-		RunCmd:        []string{"python3", "{source}"},
-		FileExtension: ".py",
+		Image:          "sandbox-judge-python:latest",
+		CompileCmd:     nil, // Interpreted
+		RunCmd:         []string{"python3", "{source}"},
+		FileExtension:  ".py",
+		TimeMultiplier: 3, // Limits are set for C++; Python is typically 3-5x slower
 	},
 	"python3": {
-		Image:         "sandbox-judge-python:latest",
-		CompileCmd:    nil,
-		RunCmd:        []string{"python3", "{source}"},
-		FileExtension: ".py",
+		Image:          "sandbox-judge-python:latest",
+		CompileCmd:     nil,
+		RunCmd:         []string{"python3", "{source}"},
+		FileExtension:  ".py",
+		TimeMultiplier: 3,
 	},
 }
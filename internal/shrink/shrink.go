@@ -0,0 +1,276 @@
+// Package shrink minimizes failing test inputs.
+//
+// Shrink repeatedly tries smaller variants of an input (fewer lines, fewer
+// tokens, numbers closer to zero) and keeps each one that still reproduces
+// the failure, until no variant does. The caller decides what "reproduces"
+// and "valid" mean, typically by running programs in the sandbox.
+package shrink
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// Predicate reports whether a candidate input has a property, such as
+// reproducing a failure or satisfying the problem's input constraints
+type Predicate func(ctx context.Context, input string) (bool, error)
+
+// Options configures a shrink
+type Options struct {
+	// Valid rejects candidates that break the input format (nil = every candidate is valid)
+	Valid Predicate
+
+	// MaxAttempts bounds the number of candidates tried (0 = no limit)
+	MaxAttempts int
+}
+
+// Result is the outcome of a shrink
+type Result struct {
+	// Input is the smallest failing input found
+	Input string
+
+	// Attempts is the number of candidates tried
+	Attempts int
+
+	// Exhausted is true if MaxAttempts stopped the shrink early
+	Exhausted bool
+}
+
+// Shrink minimizes input while fails keeps reporting true for it.
+// The original input is assumed to fail. On error the smallest failing
+// input found so far is returned along with the error.
+func Shrink(ctx context.Context, input string, fails Predicate, opts Options) (*Result, error) {
+	s := &shrinker{
+		fails:           fails,
+		opts:            opts,
+		lines:           splitLines(input),
+		trailingNewline: strings.HasSuffix(input, "\n"),
+		seen:            map[string]bool{input: true},
+	}
+
+	passes := []func(context.Context) (bool, error){s.deleteLines, s.deleteTokens, s.shrinkNumbers}
+	for progress := true; progress && !s.exhausted; {
+		progress = false
+		for _, pass := range passes {
+			shrunk, err := pass(ctx)
+			if err != nil {
+				return s.result(), err
+			}
+			progress = progress || shrunk
+		}
+	}
+
+	return s.result(), nil
+}
+
+// shrinker holds the smallest failing input found so far, as lines
+type shrinker struct {
+	fails Predicate
+	opts  Options
+
+	lines           []string
+	trailingNewline bool
+
+	seen      map[string]bool
+	attempts  int
+	exhausted bool
+}
+
+// result returns the current smallest input
+func (s *shrinker) result() *Result {
+	return &Result{
+		Input:     s.join(s.lines),
+		Attempts:  s.attempts,
+		Exhausted: s.exhausted,
+	}
+}
+
+// join turns lines back into an input with the original's final newline
+func (s *shrinker) join(lines []string) string {
+	text := strings.Join(lines, "\n")
+	if s.trailingNewline {
+		text += "\n"
+	}
+	return text
+}
+
+// try tests a candidate and adopts it if it is valid and still fails
+func (s *shrinker) try(ctx context.Context, lines []string) (bool, error) {
+	if s.exhausted {
+		return false, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	candidate := s.join(lines)
+	if s.seen[candidate] {
+		return false, nil
+	}
+	s.seen[candidate] = true
+
+	if s.opts.MaxAttempts > 0 && s.attempts >= s.opts.MaxAttempts {
+		s.exhausted = true
+		return false, nil
+	}
+	s.attempts++
+
+	if s.opts.Valid != nil {
+		valid, err := s.opts.Valid(ctx, candidate)
+		if err != nil || !valid {
+			return false, err
+		}
+	}
+
+	failed, err := s.fails(ctx, candidate)
+	if err != nil || !failed {
+		return false, err
+	}
+
+	s.lines = lines
+	return true, nil
+}
+
+// deleteLines removes chunks of lines, halving the chunk size down to one line
+func (s *shrinker) deleteLines(ctx context.Context) (bool, error) {
+	return deleteChunks(len(s.lines), func(from, to int) (bool, error) {
+		if to-from == len(s.lines) {
+			return false, nil // keep at least one line
+		}
+		return s.try(ctx, without(s.lines, from, to))
+	}, func() int { return len(s.lines) })
+}
+
+// deleteTokens removes chunks of tokens within each line
+func (s *shrinker) deleteTokens(ctx context.Context) (bool, error) {
+	progress := false
+	for i := 0; i < len(s.lines); i++ {
+		tokens := strings.Fields(s.lines[i])
+		shrunk, err := deleteChunks(len(tokens), func(from, to int) (bool, error) {
+			if to-from == len(tokens) {
+				return false, nil // deleting the whole line is deleteLines' job
+			}
+			candidate := without(tokens, from, to)
+			ok, err := s.try(ctx, replaceLine(s.lines, i, strings.Join(candidate, " ")))
+			if ok {
+				tokens = candidate
+			}
+			return ok, err
+		}, func() int { return len(tokens) })
+		if err != nil {
+			return progress, err
+		}
+		progress = progress || shrunk
+	}
+	return progress, nil
+}
+
+// shrinkNumbers moves each integer token as close to zero as the failure allows
+func (s *shrinker) shrinkNumbers(ctx context.Context) (bool, error) {
+	progress := false
+	for i := 0; i < len(s.lines); i++ {
+		for k := 0; k < len(strings.Fields(s.lines[i])); k++ {
+			shrunk, err := s.shrinkNumber(ctx, i, k)
+			if err != nil {
+				return progress, err
+			}
+			progress = progress || shrunk
+		}
+	}
+	return progress, nil
+}
+
+// shrinkNumber binary-searches the smallest magnitude for token k of line i,
+// preferring non-negative values
+func (s *shrinker) shrinkNumber(ctx context.Context, i, k int) (bool, error) {
+	value, err := strconv.ParseInt(strings.Fields(s.lines[i])[k], 10, 64)
+	if err != nil || value == 0 {
+		return false, nil
+	}
+
+	set := func(v int64) (bool, error) {
+		tokens := strings.Fields(s.lines[i])
+		tokens[k] = strconv.FormatInt(v, 10)
+		return s.try(ctx, replaceLine(s.lines, i, strings.Join(tokens, " ")))
+	}
+
+	progress := false
+	sign := int64(1)
+	if value < 0 {
+		sign = -1
+		// A positive value is simpler than a negative one
+		ok, err := set(-value)
+		if err != nil {
+			return progress, err
+		}
+		if ok {
+			sign, progress = 1, true
+		}
+	}
+
+	hi := value
+	if hi < 0 {
+		hi = -hi
+	}
+
+	// Invariant: magnitude hi fails; lo does not (-1 stands for "below zero")
+	lo := int64(-1)
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		ok, err := set(sign * mid)
+		if err != nil {
+			return progress, err
+		}
+		if ok {
+			hi, progress = mid, true
+		} else {
+			lo = mid
+		}
+	}
+	return progress, nil
+}
+
+// deleteChunks calls remove for chunks [from, to) of a sequence, starting
+// with half its length and halving down to single elements. remove reports
+// whether the chunk was deleted; size returns the current length.
+func deleteChunks(n int, remove func(from, to int) (bool, error), size func() int) (bool, error) {
+	progress := false
+	for chunk := n / 2; chunk >= 1; chunk /= 2 {
+		for from := 0; from < size(); {
+			to := from + chunk
+			if to > size() {
+				to = size()
+			}
+			ok, err := remove(from, to)
+			if err != nil {
+				return progress, err
+			}
+			if ok {
+				progress = true // the next chunk moved to from
+			} else {
+				from = to
+			}
+		}
+	}
+	return progress, nil
+}
+
+// splitLines splits an input into lines, ignoring a final newline
+func splitLines(input string) []string {
+	return strings.Split(strings.TrimSuffix(input, "\n"), "\n")
+}
+
+// without returns a copy of items with [from, to) removed
+func without(items []string, from, to int) []string {
+	out := make([]string, 0, len(items)-(to-from))
+	out = append(out, items[:from]...)
+	return append(out, items[to:]...)
+}
+
+// replaceLine returns a copy of lines with line i replaced
+func replaceLine(lines []string, i int, line string) []string {
+	out := append([]string(nil), lines...)
+	out[i] = line
+	return out
+}
@@ -0,0 +1,99 @@
+package shrink
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// containsAtLeast fails whenever some token is an integer >= limit
+func containsAtLeast(limit int) Predicate {
+	return func(_ context.Context, input string) (bool, error) {
+		for _, tok := range strings.Fields(input) {
+			if n, err := strconv.Atoi(tok); err == nil && n >= limit {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+}
+
+func TestShrink_FindsMinimalInput(t *testing.T) {
+	input := "5\n3 8 120 7\n4 500 -9\n1\n"
+
+	result, err := Shrink(context.Background(), input, containsAtLeast(100), Options{})
+	if err != nil {
+		t.Fatalf("Shrink returned error: %v", err)
+	}
+	if result.Input != "100\n" {
+		t.Errorf("Shrink = %q, want %q", result.Input, "100\n")
+	}
+	if result.Attempts == 0 || result.Exhausted {
+		t.Errorf("Unexpected stats: %+v", result)
+	}
+}
+
+func TestShrink_RespectsValidator(t *testing.T) {
+	// Valid inputs have a count line followed by exactly that many numbers
+	valid := func(_ context.Context, input string) (bool, error) {
+		lines := strings.Split(strings.TrimSuffix(input, "\n"), "\n")
+		n, err := strconv.Atoi(lines[0])
+		return err == nil && len(lines) == 2 && len(strings.Fields(lines[1])) == n, nil
+	}
+	input := "4\n3 8 120 7\n"
+
+	result, err := Shrink(context.Background(), input, containsAtLeast(100), Options{Valid: valid})
+	if err != nil {
+		t.Fatalf("Shrink returned error: %v", err)
+	}
+	// Deleting a number would break the count, so only values shrink
+	if result.Input != "4\n0 0 100 0\n" {
+		t.Errorf("Shrink = %q, want %q", result.Input, "4\n0 0 100 0\n")
+	}
+}
+
+func TestShrink_PrefersPositiveNumbers(t *testing.T) {
+	// Fails while some number has magnitude >= 10
+	fails := func(_ context.Context, input string) (bool, error) {
+		n, err := strconv.Atoi(strings.TrimSpace(input))
+		return err == nil && (n >= 10 || n <= -10), nil
+	}
+
+	result, err := Shrink(context.Background(), "-5000", fails, Options{})
+	if err != nil {
+		t.Fatalf("Shrink returned error: %v", err)
+	}
+	if result.Input != "10" {
+		t.Errorf("Shrink = %q, want %q", result.Input, "10")
+	}
+}
+
+func TestShrink_MaxAttempts(t *testing.T) {
+	input := "1 2 3 4 5 6 7 8 900\n"
+
+	result, err := Shrink(context.Background(), input, containsAtLeast(100), Options{MaxAttempts: 2})
+	if err != nil {
+		t.Fatalf("Shrink returned error: %v", err)
+	}
+	if !result.Exhausted || result.Attempts != 2 {
+		t.Errorf("Expected exhausted after 2 attempts, got %+v", result)
+	}
+	if ok, _ := containsAtLeast(100)(context.Background(), result.Input); !ok {
+		t.Errorf("Partial result %q no longer fails", result.Input)
+	}
+}
+
+func TestShrink_PredicateError(t *testing.T) {
+	boom := errors.New("sandbox unavailable")
+	fails := func(context.Context, string) (bool, error) { return false, boom }
+
+	result, err := Shrink(context.Background(), "1 2\n3\n", fails, Options{})
+	if !errors.Is(err, boom) {
+		t.Fatalf("Shrink error = %v, want %v", err, boom)
+	}
+	if result.Input != "1 2\n3\n" {
+		t.Errorf("Shrink = %q, want the original input", result.Input)
+	}
+}
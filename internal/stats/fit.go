@@ -0,0 +1,115 @@
+package stats
+
+import (
+	"math"
+	"sort"
+)
+
+// Complexity is a candidate growth rate f(n)
+type Complexity struct {
+	// Name in big-O notation, e.g. "O(n log n)"
+	Name string
+
+	// F is the growth function
+	F func(n float64) float64
+}
+
+// Complexities are the candidate growth rates tried by FitComplexity,
+// from slowest to fastest growing
+var Complexities = []Complexity{
+	{"O(1)", func(float64) float64 { return 0 }},
+	{"O(log n)", func(n float64) float64 { return math.Log2(n) }},
+	{"O(sqrt n)", math.Sqrt},
+	{"O(n)", func(n float64) float64 { return n }},
+	{"O(n log n)", func(n float64) float64 { return n * math.Log2(n) }},
+	{"O(n^2)", func(n float64) float64 { return n * n }},
+	{"O(n^3)", func(n float64) float64 { return n * n * n }},
+}
+
+// Fit is a model t(n) = A + B*f(n) fitted to measurements. A absorbs fixed
+// costs such as process start-up; B is never negative.
+type Fit struct {
+	Complexity Complexity
+	A          float64
+	B          float64
+
+	// RSS is the residual sum of squares of the fit
+	RSS float64
+
+	// R2 is the coefficient of determination (1 = perfect fit)
+	R2 float64
+}
+
+// Predict returns the fitted value at size n
+func (f Fit) Predict(n float64) float64 {
+	return f.A + f.B*f.Complexity.F(n)
+}
+
+// FitComplexity fits every candidate complexity to times measured at sizes
+// and returns the fits from best to worst. Fits are ranked by the Bayesian
+// information criterion, which charges O(1) one parameter fewer than the
+// others, so noise alone does not make a constant look like growth. Ties go
+// to the slower-growing model.
+func FitComplexity(sizes, times []float64) []Fit {
+	fits := make([]Fit, len(Complexities))
+	scores := make(map[string]float64, len(Complexities))
+	for i, c := range Complexities {
+		fits[i] = fitLinear(c, sizes, times)
+		scores[c.Name] = bic(fits[i], len(sizes))
+	}
+
+	sort.SliceStable(fits, func(i, k int) bool {
+		return scores[fits[i].Complexity.Name] < scores[fits[k].Complexity.Name]-1e-9
+	})
+	return fits
+}
+
+// bic returns the Bayesian information criterion of a fit to n points
+// (lower is better)
+func bic(f Fit, n int) float64 {
+	params := 2.0
+	if f.Complexity.Name == "O(1)" {
+		params = 1
+	}
+	// The epsilon keeps perfect fits finite
+	return float64(n)*math.Log(f.RSS/float64(n)+1e-12) + params*math.Log(float64(n))
+}
+
+// fitLinear fits t = A + B*f(n) by least squares, with B >= 0
+func fitLinear(c Complexity, sizes, times []float64) Fit {
+	n := float64(len(sizes))
+	fit := Fit{Complexity: c}
+	if n == 0 {
+		return fit
+	}
+
+	var sumX, sumY, sumXX, sumXY float64
+	for i := range sizes {
+		x, y := c.F(sizes[i]), times[i]
+		sumX += x
+		sumY += y
+		sumXX += x * x
+		sumXY += x * y
+	}
+
+	meanY := sumY / n
+	if denom := n*sumXX - sumX*sumX; denom > 0 {
+		fit.B = (n*sumXY - sumX*sumY) / denom
+	}
+	if fit.B < 0 {
+		fit.B = 0
+	}
+	fit.A = (sumY - fit.B*sumX) / n
+
+	var total float64
+	for i := range sizes {
+		r := times[i] - fit.Predict(sizes[i])
+		fit.RSS += r * r
+		total += (times[i] - meanY) * (times[i] - meanY)
+	}
+	fit.R2 = 1
+	if total > 0 {
+		fit.R2 = 1 - fit.RSS/total
+	}
+	return fit
+}
@@ -0,0 +1,48 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFitComplexity(t *testing.T) {
+	sizes := []float64{100, 200, 400, 800, 1600, 3200, 6400}
+
+	tests := map[string]func(n float64) float64{
+		"O(n)":       func(n float64) float64 { return 30 + 0.01*n },
+		"O(n log n)": func(n float64) float64 { return 30 + 0.002*n*math.Log2(n) },
+		"O(n^2)":     func(n float64) float64 { return 30 + 1e-5*n*n },
+		"O(1)":       func(n float64) float64 { return 30 },
+	}
+
+	for want, model := range tests {
+		times := make([]float64, len(sizes))
+		for i, n := range sizes {
+			// Alternate +-1% noise
+			noise := 1.0 + 0.01*float64(1-2*(i%2))
+			times[i] = model(n) * noise
+		}
+
+		fits := FitComplexity(sizes, times)
+		if got := fits[0].Complexity.Name; got != want {
+			t.Errorf("best fit = %s, want %s (fits: %v, %v)", got, want, fits[0].RSS, fits[1].RSS)
+		}
+	}
+}
+
+func TestFit_Predict(t *testing.T) {
+	sizes := []float64{10, 20, 40, 80}
+	times := []float64{25, 40, 70, 130} // 10 + 1.5n
+
+	fits := FitComplexity(sizes, times)
+	best := fits[0]
+	if best.Complexity.Name != "O(n)" {
+		t.Fatalf("best fit = %s, want O(n)", best.Complexity.Name)
+	}
+	if !almostEqual(best.A, 10) || !almostEqual(best.B, 1.5) || !almostEqual(best.R2, 1) {
+		t.Errorf("fit = %+v, want A=10 B=1.5 R2=1", best)
+	}
+	if got := best.Predict(1000); !almostEqual(got, 1510) {
+		t.Errorf("Predict(1000) = %v, want 1510", got)
+	}
+}
@@ -0,0 +1,145 @@
+// Package stats provides the summary statistics and significance tests used
+// to report benchmark timings.
+package stats
+
+import (
+	"math"
+	"sort"
+)
+
+// Summary describes a set of samples
+type Summary struct {
+	N      int
+	Min    float64
+	Max    float64
+	Mean   float64
+	Median float64
+	P95    float64
+	StdDev float64 // Sample standard deviation (0 for fewer than two samples)
+}
+
+// Summarize computes summary statistics of samples
+func Summarize(samples []float64) Summary {
+	if len(samples) == 0 {
+		return Summary{}
+	}
+
+	sorted := append([]float64(nil), samples...)
+	sort.Float64s(sorted)
+
+	var sum float64
+	for _, x := range sorted {
+		sum += x
+	}
+	mean := sum / float64(len(sorted))
+
+	var variance float64
+	if len(sorted) > 1 {
+		for _, x := range sorted {
+			variance += (x - mean) * (x - mean)
+		}
+		variance /= float64(len(sorted) - 1)
+	}
+
+	return Summary{
+		N:      len(sorted),
+		Min:    sorted[0],
+		Max:    sorted[len(sorted)-1],
+		Mean:   mean,
+		Median: Percentile(sorted, 50),
+		P95:    Percentile(sorted, 95),
+		StdDev: math.Sqrt(variance),
+	}
+}
+
+// Percentile returns the p-th percentile (0-100) of sorted samples,
+// interpolating linearly between the closest ranks
+func Percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := p / 100 * float64(len(sorted)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+	return sorted[lo] + (rank-float64(lo))*(sorted[hi]-sorted[lo])
+}
+
+// Comparison is the outcome of comparing two sets of samples
+type Comparison struct {
+	// U is the Mann-Whitney U statistic of the first set
+	U float64
+
+	// P is the two-sided p-value that both sets come from the same distribution
+	P float64
+
+	// Ratio is the second set's median divided by the first's
+	Ratio float64
+}
+
+// Significant reports whether the difference is significant at level alpha
+func (c Comparison) Significant(alpha float64) bool {
+	return c.P < alpha
+}
+
+// Compare tests whether two sets of samples differ, using the Mann-Whitney
+// U test. It makes no assumption about the distribution, which suits
+// timings with long tails. The p-value uses the normal approximation with
+// tie and continuity corrections, which is reasonable from about 5 samples
+// per set.
+func Compare(a, b []float64) Comparison {
+	c := Comparison{P: 1}
+	if ma := Summarize(a).Median; ma != 0 {
+		c.Ratio = Summarize(b).Median / ma
+	}
+	if len(a) == 0 || len(b) == 0 {
+		return c
+	}
+
+	// Rank the pooled samples, averaging ranks of ties
+	type sample struct {
+		value float64
+		first bool
+	}
+	pooled := make([]sample, 0, len(a)+len(b))
+	for _, x := range a {
+		pooled = append(pooled, sample{x, true})
+	}
+	for _, x := range b {
+		pooled = append(pooled, sample{x, false})
+	}
+	sort.Slice(pooled, func(i, k int) bool { return pooled[i].value < pooled[k].value })
+
+	var rankSumA, tieTerm float64
+	for i := 0; i < len(pooled); {
+		k := i
+		for k < len(pooled) && pooled[k].value == pooled[i].value {
+			k++
+		}
+		rank := float64(i+k+1) / 2 // average of ranks i+1..k
+		for m := i; m < k; m++ {
+			if pooled[m].first {
+				rankSumA += rank
+			}
+		}
+		t := float64(k - i)
+		tieTerm += t*t*t - t
+		i = k
+	}
+
+	n1, n2 := float64(len(a)), float64(len(b))
+	n := n1 + n2
+	c.U = rankSumA - n1*(n1+1)/2
+
+	mean := n1 * n2 / 2
+	variance := n1 * n2 / 12 * ((n + 1) - tieTerm/(n*(n-1)))
+	if variance <= 0 {
+		return c // every sample is equal
+	}
+
+	z := (math.Abs(c.U-mean) - 0.5) / math.Sqrt(variance)
+	if z < 0 {
+		z = 0
+	}
+	c.P = math.Erfc(z / math.Sqrt2)
+	return c
+}
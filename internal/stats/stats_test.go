@@ -0,0 +1,82 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestSummarize(t *testing.T) {
+	s := Summarize([]float64{5, 1, 4, 2, 3})
+
+	if s.N != 5 || s.Min != 1 || s.Max != 5 || s.Mean != 3 || s.Median != 3 {
+		t.Errorf("Unexpected summary: %+v", s)
+	}
+	if !almostEqual(s.P95, 4.8) {
+		t.Errorf("P95 = %v, want 4.8", s.P95)
+	}
+	if !almostEqual(s.StdDev, math.Sqrt(2.5)) {
+		t.Errorf("StdDev = %v, want %v", s.StdDev, math.Sqrt(2.5))
+	}
+
+	if got := Summarize(nil); got != (Summary{}) {
+		t.Errorf("Summarize(nil) = %+v, want zero summary", got)
+	}
+	if got := Summarize([]float64{7}); got.StdDev != 0 || got.P95 != 7 {
+		t.Errorf("Summarize single sample = %+v", got)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := []float64{10, 20, 30, 40}
+	tests := map[float64]float64{0: 10, 50: 25, 100: 40, 95: 38.5}
+	for p, want := range tests {
+		if got := Percentile(sorted, p); !almostEqual(got, want) {
+			t.Errorf("Percentile(%v) = %v, want %v", p, got, want)
+		}
+	}
+}
+
+func TestCompare_Significant(t *testing.T) {
+	fast := []float64{10, 11, 12, 10, 11, 12, 10, 11}
+	slow := []float64{20, 21, 22, 20, 21, 22, 20, 21}
+
+	c := Compare(fast, slow)
+	if c.U != 0 {
+		t.Errorf("U = %v, want 0 (every fast sample is smaller)", c.U)
+	}
+	if !c.Significant(0.05) {
+		t.Errorf("Expected significant difference, p = %v", c.P)
+	}
+	if !almostEqual(c.Ratio, 21.0/11.0) {
+		t.Errorf("Ratio = %v, want %v", c.Ratio, 21.0/11.0)
+	}
+}
+
+func TestCompare_NotSignificant(t *testing.T) {
+	a := []float64{10, 12, 11, 13, 9, 12}
+	b := []float64{11, 10, 12, 13, 12, 9}
+
+	if c := Compare(a, b); c.Significant(0.05) {
+		t.Errorf("Expected no significant difference, p = %v", c.P)
+	}
+	if c := Compare([]float64{5, 5, 5}, []float64{5, 5, 5}); c.P != 1 {
+		t.Errorf("Identical samples p = %v, want 1", c.P)
+	}
+}
+
+func TestCompare_KnownValue(t *testing.T) {
+	// U counts the pairs where a's sample is larger: 6 beats 3, 4 and 5
+	c := Compare([]float64{1, 2, 6}, []float64{3, 4, 5, 7})
+	if c.U != 3 {
+		t.Errorf("U = %v, want 3", c.U)
+	}
+	// mean 6, variance 3*4*8/12 = 8, z = (3 - 0.5) / sqrt(8)
+	want := math.Erfc(2.5 / math.Sqrt(8) / math.Sqrt2)
+	if !almostEqual(c.P, want) {
+		t.Errorf("P = %v, want %v", c.P, want)
+	}
+}
@@ -0,0 +1,107 @@
+// Package watch reports changes to a file or directory tree by polling,
+// which works the same on every platform and filesystem, including the
+// bind mounts and network drives where change notifications are unreliable.
+package watch
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultInterval is how often watched files are polled
+const DefaultInterval = 300 * time.Millisecond
+
+// fileState is what a poll compares to detect a change
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// Changes polls path (a file, or every file under a directory) and sends
+// on the returned channel after it changes, once the files have stayed the
+// same for one more interval, so that an editor's multi-step save counts
+// as one change. Files and directories starting with "." are ignored. The
+// channel is closed when ctx is done.
+func Changes(ctx context.Context, path string, interval time.Duration) <-chan struct{} {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	changes := make(chan struct{}, 1)
+	go func() {
+		defer close(changes)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		last := snapshot(path)
+		pending := false
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			current := snapshot(path)
+			if !equal(current, last) {
+				last = current
+				pending = true
+				continue
+			}
+			if pending {
+				pending = false
+				// A change already waiting to be read covers this one
+				select {
+				case changes <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+	return changes
+}
+
+// snapshot records the state of every watched file. Missing or unreadable
+// paths give an empty snapshot, e.g. while an editor replaces a file.
+func snapshot(path string) map[string]fileState {
+	files := make(map[string]fileState)
+	filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if p != path && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		files[p] = fileState{modTime: info.ModTime(), size: info.Size()}
+		return nil
+	})
+	return files
+}
+
+// equal reports whether two snapshots hold the same files in the same state
+func equal(a, b map[string]fileState) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for p, sa := range a {
+		sb, ok := b[p]
+		if !ok || !sa.modTime.Equal(sb.modTime) || sa.size != sb.size {
+			return false
+		}
+	}
+	return true
+}
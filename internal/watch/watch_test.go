@@ -0,0 +1,95 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testInterval = 10 * time.Millisecond
+
+// expectChange waits for one change notification
+func expectChange(t *testing.T, changes <-chan struct{}) {
+	t.Helper()
+	select {
+	case <-changes:
+	case <-time.After(time.Second):
+		t.Fatal("no change reported")
+	}
+}
+
+// expectQuiet checks that no change is reported for a while
+func expectQuiet(t *testing.T, changes <-chan struct{}) {
+	t.Helper()
+	select {
+	case <-changes:
+		t.Fatal("unexpected change reported")
+	case <-time.After(10 * testInterval):
+	}
+}
+
+func write(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestChanges_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "solution.py")
+	write(t, path, "print(1)\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes := Changes(ctx, path, testInterval)
+
+	expectQuiet(t, changes)
+
+	write(t, path, "print(12)\n")
+	expectChange(t, changes)
+	expectQuiet(t, changes)
+
+	// Deleting and recreating the file (as some editors save) is a change
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	expectChange(t, changes)
+	write(t, path, "print(123)\n")
+	expectChange(t, changes)
+}
+
+func TestChanges_Directory(t *testing.T) {
+	dir := t.TempDir()
+	write(t, filepath.Join(dir, "solution.py"), "")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes := Changes(ctx, dir, testInterval)
+
+	// Hidden files, such as editor swap files, are ignored
+	write(t, filepath.Join(dir, ".solution.py.swp"), "x")
+	expectQuiet(t, changes)
+
+	if err := os.Mkdir(filepath.Join(dir, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	write(t, filepath.Join(dir, "sub", "helper.py"), "x")
+	expectChange(t, changes)
+}
+
+func TestChanges_ClosedOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	changes := Changes(ctx, t.TempDir(), testInterval)
+	cancel()
+
+	select {
+	case _, ok := <-changes:
+		if ok {
+			t.Error("change reported after cancel")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("channel not closed after cancel")
+	}
+}
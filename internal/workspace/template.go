@@ -0,0 +1,60 @@
+package workspace
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/marv972228/sandbox_judge/internal/harness"
+	"github.com/marv972228/sandbox_judge/internal/problem"
+)
+
+// TemplatesDir is the directory inside a problem that holds its own starter
+// files, named like solutions (templates/solution.cpp)
+const TemplatesDir = "templates"
+
+// defaults holds the per-language starters with fast I/O boilerplate
+//
+//go:embed templates/*.tmpl
+var defaults embed.FS
+
+// Starter returns the starter code for a problem in a language and a short
+// description of where it came from. A template shipped with the problem
+// wins; otherwise function-signature problems get a stub from the
+// language's harness, and stdin problems the default template. Signature
+// problems are refused in languages without a harness, since the judge
+// could not run the solution.
+func Starter(p *problem.Problem, problemDir, language string) (content, source string, err error) {
+	ext, ok := Extension(language)
+	if !ok {
+		return "", "", fmt.Errorf("unknown language %q", language)
+	}
+
+	var h harness.Harness
+	if p.Signature != nil {
+		if h, err = harness.For(language); err != nil {
+			return "", "", err
+		}
+	}
+
+	path := filepath.Join(problemDir, TemplatesDir, SolutionName+ext)
+	data, err := os.ReadFile(path)
+	if err == nil {
+		return string(data), "problem template " + filepath.Join(TemplatesDir, SolutionName+ext), nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return "", "", fmt.Errorf("failed to read template: %w", err)
+	}
+
+	if h != nil {
+		return h.Stub(p.Signature), "function stub for " + p.Signature.Function, nil
+	}
+
+	data, err = defaults.ReadFile("templates/" + SolutionName + ext + ".tmpl")
+	if err != nil {
+		return "", "", fmt.Errorf("no default template for %s", language)
+	}
+	return string(data), "default " + language + " template", nil
+}
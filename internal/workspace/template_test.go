@@ -0,0 +1,73 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/marv972228/sandbox_judge/internal/problem"
+)
+
+func TestStarter_Defaults(t *testing.T) {
+	p := &problem.Problem{ID: "p"}
+	for _, language := range Languages() {
+		content, source, err := Starter(p, t.TempDir(), language)
+		if err != nil {
+			t.Errorf("%s: %v", language, err)
+			continue
+		}
+		if content == "" || source != "default "+language+" template" {
+			t.Errorf("%s: source %q, %d bytes", language, source, len(content))
+		}
+	}
+
+	content, _, _ := Starter(p, t.TempDir(), "cpp")
+	if !strings.Contains(content, "sync_with_stdio(false)") {
+		t.Errorf("cpp template lacks fast I/O:\n%s", content)
+	}
+}
+
+func TestStarter_Precedence(t *testing.T) {
+	dir := t.TempDir()
+	p := &problem.Problem{ID: "p", Signature: &problem.Signature{Function: "solve", Returns: problem.TypeInt}}
+
+	// Function-signature problems get the harness stub
+	content, source, err := Starter(p, dir, "python")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(content, "def solve(self) -> int:") || !strings.Contains(source, "function stub") {
+		t.Errorf("signature starter from %q:\n%s", source, content)
+	}
+	if _, _, err := Starter(p, dir, "cpp"); err == nil {
+		t.Error("expected an error for a signature problem in a language without a harness")
+	}
+
+	// A problem template wins over both
+	templates := filepath.Join(dir, TemplatesDir)
+	if err := os.MkdirAll(templates, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"solution.py", "solution.cpp"} {
+		if err := os.WriteFile(filepath.Join(templates, name), []byte("# custom\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	content, source, err = Starter(p, dir, "python")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content != "# custom\n" || source != "problem template "+filepath.Join("templates", "solution.py") {
+		t.Errorf("problem template: source %q, content %q", source, content)
+	}
+	content, _, err = Starter(&problem.Problem{ID: "p"}, dir, "cpp")
+	if err != nil || content != "# custom\n" {
+		t.Errorf("stdin problem template = %q, %v", content, err)
+	}
+
+	// ...but not over a missing harness, which judge run would need
+	if _, _, err := Starter(p, dir, "cpp"); err == nil {
+		t.Error("expected an error for a signature problem template in a language without a harness")
+	}
+}
@@ -0,0 +1,115 @@
+// Package workspace lays out solutions at conventional paths and writes
+// starter files for them from templates.
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// SolutionName is the base name of solution files, before the extension
+const SolutionName = "solution"
+
+// extensions maps language identifiers to source file extensions, matching
+// the judge's language detection
+var extensions = map[string]string{
+	"python":     ".py",
+	"cpp":        ".cpp",
+	"c":          ".c",
+	"java":       ".java",
+	"go":         ".go",
+	"rust":       ".rs",
+	"javascript": ".js",
+}
+
+// aliases are other accepted names for languages
+var aliases = map[string]string{
+	"py":      "python",
+	"python3": "python",
+	"c++":     "cpp",
+	"rs":      "rust",
+	"js":      "javascript",
+	"node":    "javascript",
+}
+
+// Language resolves a language name or alias (e.g. "py", "c++") to its
+// identifier.
+func Language(name string) (string, error) {
+	name = strings.ToLower(name)
+	if alias, ok := aliases[name]; ok {
+		name = alias
+	}
+	if _, ok := extensions[name]; !ok {
+		return "", fmt.Errorf("unknown language %q (available: %s)", name, strings.Join(Languages(), ", "))
+	}
+	return name, nil
+}
+
+// Languages returns the language identifiers, sorted by name.
+func Languages() []string {
+	languages := make([]string, 0, len(extensions))
+	for language := range extensions {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// Extension returns the source file extension of a language identifier.
+func Extension(language string) (string, bool) {
+	ext, ok := extensions[language]
+	return ext, ok
+}
+
+// Workspace holds one directory of solutions per problem:
+// <root>/<problem-id>/solution.<ext>
+type Workspace struct {
+	root string
+}
+
+// New creates a workspace rooted at the given directory.
+func New(root string) *Workspace {
+	return &Workspace{root: root}
+}
+
+// Dir returns the directory holding a problem's solutions.
+func (w *Workspace) Dir(problemID string) string {
+	return filepath.Join(w.root, problemID)
+}
+
+// Path returns the conventional solution path for a problem and language.
+func (w *Workspace) Path(problemID, language string) (string, error) {
+	ext, ok := Extension(language)
+	if !ok {
+		return "", fmt.Errorf("unknown language %q", language)
+	}
+	return filepath.Join(w.Dir(problemID), SolutionName+ext), nil
+}
+
+// Find returns a problem's solution in the workspace. With solutions in
+// several languages, the most recently modified one wins.
+func (w *Workspace) Find(problemID string) (string, error) {
+	var found string
+	var newest int64
+	for _, language := range Languages() {
+		path, _ := w.Path(problemID, language)
+		info, err := os.Stat(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if found == "" || info.ModTime().UnixNano() > newest {
+			found, newest = path, info.ModTime().UnixNano()
+		}
+	}
+	if found == "" {
+		return "", fmt.Errorf("no solution for %s in %s (create one with `judge init %s`)", problemID, w.Dir(problemID), problemID)
+	}
+	return found, nil
+}
@@ -0,0 +1,67 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLanguage(t *testing.T) {
+	tests := map[string]string{"python": "python", "py": "python", "C++": "cpp", "rs": "rust", "js": "javascript"}
+	for name, want := range tests {
+		if got, err := Language(name); err != nil || got != want {
+			t.Errorf("Language(%q) = %q, %v; want %q", name, got, err, want)
+		}
+	}
+
+	if _, err := Language("cobol"); err == nil || !strings.Contains(err.Error(), "available:") {
+		t.Errorf("Language(cobol) error = %v, want the available languages", err)
+	}
+}
+
+func TestPath(t *testing.T) {
+	w := New("ws")
+	got, err := w.Path("two-sum", "cpp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join("ws", "two-sum", "solution.cpp"); got != want {
+		t.Errorf("Path = %q, want %q", got, want)
+	}
+}
+
+func TestFind(t *testing.T) {
+	root := t.TempDir()
+	w := New(root)
+
+	if _, err := w.Find("p"); err == nil || !strings.Contains(err.Error(), "judge init p") {
+		t.Errorf("Find in an empty workspace: error = %v, want a hint to run init", err)
+	}
+
+	dir := w.Dir("p")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-time.Hour)
+	for _, name := range []string{"solution.py", "solution.cpp", "notes.txt"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if name != "solution.cpp" {
+			if err := os.Chtimes(path, old, old); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	got, err := w.Find("p")
+	if err != nil {
+		t.Fatalf("Find returned error: %v", err)
+	}
+	if want := filepath.Join(dir, "solution.cpp"); got != want {
+		t.Errorf("Find = %q, want the most recently modified %q", got, want)
+	}
+}
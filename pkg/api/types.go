@@ -1,2 +1,174 @@
 // Package api defines shared types used by CLI and web interfaces.
+//
+// The types are a versioned wire format: JSON field names are fixed, times
+// are in milliseconds and memory in bytes or megabytes as the field name
+// says. Fields may be added within a schema version; renaming, removing or
+// changing the meaning of one requires a new SchemaVersion.
 package api
+
+// SchemaVersion identifies the wire format of the types in this package
+const SchemaVersion = "v1"
+
+// Verdict is the outcome of a test case or submission
+type Verdict string
+
+const (
+	VerdictAccepted            Verdict = "AC"   // Correct answer
+	VerdictWrongAnswer         Verdict = "WA"   // Incorrect output
+	VerdictPresentationError   Verdict = "PE"   // Right answer, wrong format
+	VerdictTimeLimitExceeded   Verdict = "TLE"  // Exceeded time limit
+	VerdictMemoryLimitExceeded Verdict = "MLE"  // Exceeded memory limit
+	VerdictRuntimeError        Verdict = "RE"   // Crashed or non-zero exit
+	VerdictCompilationError    Verdict = "CE"   // Failed to compile
+	VerdictSystemError         Verdict = "SE"   // Internal judge error
+	VerdictSkipped             Verdict = "SKIP" // Not run due to the evaluation policy
+)
+
+// Problem is a problem statement with its limits
+type Problem struct {
+	SchemaVersion string `json:"schema_version"`
+
+	ID           string    `json:"id"`
+	Title        string    `json:"title"`
+	Difficulty   string    `json:"difficulty"`
+	Tags         []string  `json:"tags"`
+	Description  string    `json:"description"`
+	InputFormat  string    `json:"input_format,omitempty"`
+	OutputFormat string    `json:"output_format,omitempty"`
+	Constraints  []string  `json:"constraints,omitempty"`
+	Examples     []Example `json:"examples,omitempty"`
+
+	// TimeLimitMS is the base per-test time limit; languages may differ
+	TimeLimitMS int `json:"time_limit_ms"`
+
+	// TimeLimitOverrides are per-language time limits, e.g. {"python": "3x"}
+	TimeLimitOverrides map[string]string `json:"time_limit_overrides,omitempty"`
+
+	MemoryLimitMB int `json:"memory_limit_mb"`
+
+	// Subtasks lists the scored test groups (empty if scoring is pass/fail)
+	Subtasks []Subtask `json:"subtasks,omitempty"`
+
+	// Signature is the function solutions implement (absent if they read stdin)
+	Signature *Signature `json:"signature,omitempty"`
+}
+
+// Signature is a function-signature problem's function. Types are "int",
+// "float", "bool", "string", "list", "tree" or arrays such as "int[][]".
+type Signature struct {
+	Function string  `json:"function"`
+	Params   []Param `json:"params"`
+	Returns  string  `json:"returns"`
+}
+
+// Param is a named, typed function parameter
+type Param struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Example is a sample input and output shown in the problem statement
+type Example struct {
+	Input       string `json:"input"`
+	Output      string `json:"output"`
+	Explanation string `json:"explanation,omitempty"`
+}
+
+// Subtask is a group of test cases scored together
+type Subtask struct {
+	Name   string  `json:"name"`
+	Points float64 `json:"points"`
+}
+
+// Submission is a solution submitted for judging
+type Submission struct {
+	SchemaVersion string `json:"schema_version"`
+
+	ProblemID string `json:"problem_id"`
+
+	// Language is the solution's language, e.g. "python" (empty = detect
+	// from Filename)
+	Language string `json:"language,omitempty"`
+
+	// Filename is the solution's file name, e.g. "solution.py"
+	Filename string `json:"filename"`
+
+	// Source is the solution's source code
+	Source string `json:"source"`
+}
+
+// TestResult is the outcome of one test case
+type TestResult struct {
+	// Name of the test case, e.g. "hidden/3"
+	Name string `json:"name"`
+
+	Description string `json:"description,omitempty"`
+	Sample      bool   `json:"sample,omitempty"`
+
+	// Hidden tests never carry input or output
+	Hidden bool `json:"hidden,omitempty"`
+
+	Verdict Verdict `json:"verdict"`
+
+	// Score is the credit earned in [0, 1]
+	Score float64 `json:"score"`
+
+	TimeMS      float64 `json:"time_ms"`
+	TimeLimitMS int64   `json:"time_limit_ms"`
+
+	// MemoryBytes is the peak memory usage (0 if not measured)
+	MemoryBytes int64 `json:"memory_bytes,omitempty"`
+
+	// Input, Expected and Actual are only set for failed, visible tests
+	Input    string `json:"input,omitempty"`
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+
+	// Message explains why the output was rejected
+	Message string `json:"message,omitempty"`
+
+	// Error describes a runtime or system error
+	Error string `json:"error,omitempty"`
+}
+
+// SubtaskResult is the score of one subtask
+type SubtaskResult struct {
+	Name   string  `json:"name"`
+	Score  float64 `json:"score"`
+	Points float64 `json:"points"`
+	Passed int     `json:"passed"`
+	Total  int     `json:"total"`
+
+	// BlockedBy names an unsolved dependency that zeroed the score
+	BlockedBy string `json:"blocked_by,omitempty"`
+
+	// Incomplete is true if the test selection left out some of the
+	// subtask's tests; it is then not scored or counted in max_score
+	Incomplete bool `json:"incomplete,omitempty"`
+}
+
+// Result is the outcome of judging a submission
+type Result struct {
+	SchemaVersion string `json:"schema_version"`
+
+	ProblemID string  `json:"problem_id"`
+	Language  string  `json:"language"`
+	Verdict   Verdict `json:"verdict"`
+
+	Passed  int `json:"passed"`
+	Skipped int `json:"skipped"`
+	Total   int `json:"total"`
+
+	// TimeMS is the sum of all test case times
+	TimeMS float64 `json:"time_ms"`
+
+	// TimeLimitMS is the per-test time limit for Language
+	TimeLimitMS int64 `json:"time_limit_ms"`
+
+	// Score and MaxScore are points across subtasks (0 without subtasks)
+	Score    float64 `json:"score"`
+	MaxScore float64 `json:"max_score"`
+
+	Subtasks []SubtaskResult `json:"subtasks,omitempty"`
+	Tests    []TestResult    `json:"tests"`
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// The JSON field names are the wire format; changing one breaks clients
+func TestResultJSON(t *testing.T) {
+	r := Result{
+		SchemaVersion: SchemaVersion,
+		ProblemID:     "two-sum",
+		Language:      "python",
+		Verdict:       VerdictWrongAnswer,
+		Passed:        1,
+		Total:         2,
+		TimeMS:        12.5,
+		TimeLimitMS:   3000,
+		Tests: []TestResult{
+			{Name: "sample/1", Sample: true, Verdict: VerdictAccepted, Score: 1, TimeMS: 4, TimeLimitMS: 3000},
+			{Name: "hidden/1", Verdict: VerdictWrongAnswer, TimeMS: 8.5, TimeLimitMS: 3000, MemoryBytes: 1024,
+				Input: "1\n", Expected: "2\n", Actual: "3\n", Message: "line 1 differs"},
+		},
+	}
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"schema_version":"v1","problem_id":"two-sum","language":"python","verdict":"WA",` +
+		`"passed":1,"skipped":0,"total":2,"time_ms":12.5,"time_limit_ms":3000,"score":0,"max_score":0,` +
+		`"tests":[{"name":"sample/1","sample":true,"verdict":"AC","score":1,"time_ms":4,"time_limit_ms":3000},` +
+		`{"name":"hidden/1","verdict":"WA","score":0,"time_ms":8.5,"time_limit_ms":3000,"memory_bytes":1024,` +
+		`"input":"1\n","expected":"2\n","actual":"3\n","message":"line 1 differs"}]}`
+	if string(data) != want {
+		t.Errorf("JSON mismatch\n got: %s\nwant: %s", data, want)
+	}
+}
@@ -0,0 +1,44 @@
+#!/usr/bin/env python3
+"""
+Two-sum input generator.
+Usage: gen.py <seed> [size] [kind]
+
+Without a size (as `judge stress` calls it) the array has 2-8 elements.
+
+Kinds:
+- random (default): distinct positive values; the target is the sum of the
+  two largest, so exactly one pair adds up to it
+- worst: the answer is the last two elements, so a naive O(n^2) solution
+  checks every pair
+- negative: like worst, but the answer pairs a negative with a positive
+- duplicates: many repeated values, with the answer at the end
+"""
+
+import random
+import sys
+
+random.seed(int(sys.argv[1]))
+
+n = int(sys.argv[2]) if len(sys.argv) > 2 else random.randint(2, 8)
+kind = sys.argv[3] if len(sys.argv) > 3 else "random"
+
+if kind == "worst":
+    # 1..n-2 cannot reach the target, only the last two can
+    nums = list(range(1, n - 1)) + [1000000, 2000000]
+    target = 3000000
+elif kind == "negative":
+    nums = list(range(1, n - 1)) + [-999999, 1999999]
+    target = 1000000
+elif kind == "duplicates":
+    nums = [1] * (n // 2) + [2] * (n // 2 - 2) + [1000000, 2000000]
+    target = 3000000
+elif kind == "random":
+    # Values up to 5*10^8 keep the target within 10^9
+    nums = random.sample(range(1, 50 if n <= 8 else 5 * 10**8), n)
+    i, j = sorted(range(n), key=lambda k: nums[k])[-2:]
+    target = nums[i] + nums[j]
+else:
+    sys.exit(f"unknown kind: {kind}")
+
+print(" ".join(map(str, nums)))
+print(target)
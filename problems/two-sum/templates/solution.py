@@ -0,0 +1,14 @@
+# Two Sum
+# Line 1: the array, line 2: the target; print the two indices
+
+
+def two_sum(nums, target):
+    # Return the indices of the two numbers that add up to target
+    return []
+
+
+nums = list(map(int, input().split()))
+target = int(input())
+
+result = two_sum(nums, target)
+print(*result)
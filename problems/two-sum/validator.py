@@ -0,0 +1,57 @@
+#!/usr/bin/env python3
+"""
+Input validator for two-sum. Reads one input on stdin and exits 0 if it
+meets the constraints in problem.yaml; otherwise prints the broken
+constraint to stderr and exits 1.
+"""
+
+import sys
+from collections import Counter
+
+MAX_N = 10**4
+MAX_VALUE = 10**9
+
+
+def fail(message):
+    print(message, file=sys.stderr)
+    sys.exit(1)
+
+
+def parse_ints(line, lineno):
+    try:
+        return [int(tok) for tok in line.split()]
+    except ValueError:
+        fail(f"line {lineno}: expected integers")
+
+
+lines = sys.stdin.read().split("\n")
+if lines and lines[-1] == "":
+    lines.pop()
+if len(lines) != 2:
+    fail(f"expected 2 lines, got {len(lines)}")
+
+nums = parse_ints(lines[0], 1)
+target = parse_ints(lines[1], 2)
+
+if not 2 <= len(nums) <= MAX_N:
+    fail(f"2 <= nums.length <= 10^4 violated: length is {len(nums)}")
+for i, v in enumerate(nums):
+    if abs(v) > MAX_VALUE:
+        fail(f"-10^9 <= nums[i] <= 10^9 violated: nums[{i}] = {v}")
+if len(target) != 1:
+    fail(f"line 2: expected a single target, got {len(target)} values")
+target = target[0]
+if abs(target) > MAX_VALUE:
+    fail(f"-10^9 <= target <= 10^9 violated: target = {target}")
+
+# Count index pairs i < j with nums[i] + nums[j] == target
+counts = Counter(nums)
+pairs = 0
+for v, c in counts.items():
+    w = target - v
+    if v < w:
+        pairs += c * counts.get(w, 0)
+    elif v == w:
+        pairs += c * (c - 1) // 2
+if pairs != 1:
+    fail(f"only one valid answer exists violated: {pairs} pairs sum to {target}")
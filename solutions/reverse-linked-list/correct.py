@@ -0,0 +1,10 @@
+# Reverse Linked List solution
+# The judge's harness builds the ListNode list and prints the result
+
+class Solution:
+    def reverseList(self, head: Optional[ListNode]) -> Optional[ListNode]:
+        prev = None
+        while head:
+            # Point the current node back, then step forward
+            head.next, prev, head = prev, head, head.next
+        return prev